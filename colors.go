@@ -8,11 +8,8 @@ import (
 	"fmt"
 	"image/color"
 	"math"
-	"regexp"
 	"strconv"
 	"strings"
-
-	"github.com/kenshaw/colors/strcase"
 )
 
 // Color is a color. Effectively the same as [color.NRGBA], but with a
@@ -22,9 +19,10 @@ type Color struct {
 	NamedColor NamedColor
 }
 
-// New creates a new color, and looks up the named color.
+// New creates a new color, and looks up the named color in the
+// [DefaultRegistry]. See [Registry.New].
 func New(r, g, b, a uint8) Color {
-	return Color{r, g, b, a, lookup[mapKey(r, g, b, a)]}
+	return DefaultRegistry.New(r, g, b, a)
 }
 
 // ToColor creates a color.
@@ -46,25 +44,46 @@ func ToColor(r, g, b, a uint8, name string) Color {
 //	"mistyrose"
 //	"MISTY ROSE"
 //	"rgb(255, 228, 225)"
-//	"rgba(255, 228, 225, 255)"
+//	"rgb(255 228 225 / 100%)"
+//	"rgb(100% 89.41% 88.24%)"
+//	"rgba(255, 228, 225, 1)"
 //	"hex(ff, e4, e1)"
 //	"hex(ff, e4, e1, ff)"
+//	"hsl(6, 100%, 94.12%)"
+//	"hsla(6deg, 100%, 94.12%, 1)"
+//	"hwb(6 88.24% 0%)"
+//	"lab(92.76 9.2 5.03)"
+//	"lch(92.76 10.48 28.64)"
+//	"oklab(94% 0.0272 0.0128)"
+//	"oklch(94.001% 0.03008 25.281)"
+//	"color(srgb 1 0.8941 0.8824)"
+//	"color-mix(in srgb, mistyrose 50%, #ffe4e1)"
+//	"rgb(from mistyrose r g b)"
+//	"light-dark(mistyrose, black)"
 //	"#ffe4e1"
 //	"#ffe4e1ff"
-func Parse(s string) (Color, error) {
-	s = strings.ToLower(strings.TrimSpace(s))
-	for _, f := range []func(string) (Color, bool){
-		FromWeb,
-		FromName,
-		FromRGB,
-		FromRGBA,
-		FromHex,
-	} {
-		if c, ok := f(s); ok {
-			return c, nil
-		}
-	}
-	return Color{}, ErrInvalidColor
+//	"0xffe4e1"
+//	"0xffffe4e1"
+//	"ffe4e1"
+//	"rgb:ffff/e4e4/e1e1"
+//
+// The rgb and rgba functions are parsed per the CSS Color Level 4 spec.
+// See [WithLegacyAlpha] for parsing a rgba alpha as 0-255, and
+// [WithVarResolver] for resolving var() references, and [WithContext] for
+// resolving currentcolor and system colors (ex: Canvas), which are invalid
+// without a context, and light-dark().
+//
+// Returns a [*ParseError] describing where and why s is invalid when s
+// cannot be parsed. Uses the [DefaultParser]. See [Parser] for configuring
+// the formats that are parsed.
+func Parse(s string, opts ...Option) (Color, error) {
+	return DefaultParser.With(opts...).Parse(s)
+}
+
+// ParseBytes parses a color in b, as with [Parse]. Does not allocate when b
+// is a valid color, and does not retain b.
+func ParseBytes(b []byte, opts ...Option) (Color, error) {
+	return DefaultParser.With(opts...).ParseBytes(b)
 }
 
 // FromColor converts a standard [color.Color] to a color.
@@ -76,78 +95,94 @@ func FromColor(clr color.Color) Color {
 	return New(c.R, c.G, c.B, c.A)
 }
 
-// FromWeb converts a web string to a color.
+// FromWeb converts a web string to a color, ex: #ffe4e1, #fe1, #ffe4e1ff,
+// #fe1f, 0xffe4e1, 0xffffe4e1, or ffe4e1.
 //
-// Cribbed from https://stackoverflow.com/questions/54197913/parse-hex-string-to-image-color
-func FromWeb(s string) (c Color, ok bool) {
-	c.A = 0xff
-	n := len(s)
-	if n == 0 || s[0] != '#' {
-		return c, false
-	}
-	hexToByte := func(b byte) byte {
-		switch {
-		case b >= '0' && b <= '9':
-			return b - '0'
-		case b >= 'a' && b <= 'f':
-			return b - 'a' + 10
-		case b >= 'A' && b <= 'F':
-			return b - 'A' + 10
-		}
-		ok = false
-		return 0
-	}
-	switch ok = true; n {
-	case 9:
-		c.R = hexToByte(s[1])<<4 + hexToByte(s[2])
-		c.G = hexToByte(s[3])<<4 + hexToByte(s[4])
-		c.B = hexToByte(s[5])<<4 + hexToByte(s[6])
-		c.A = hexToByte(s[7])<<4 + hexToByte(s[8])
-	case 7:
-		c.R = hexToByte(s[1])<<4 + hexToByte(s[2])
-		c.G = hexToByte(s[3])<<4 + hexToByte(s[4])
-		c.B = hexToByte(s[5])<<4 + hexToByte(s[6])
-	case 4:
-		c.R = hexToByte(s[1]) * 17
-		c.G = hexToByte(s[2]) * 17
-		c.B = hexToByte(s[3]) * 17
-	default:
-		ok = false
-	}
-	if ok {
-		c.NamedColor = lookup[mapKey(c.R, c.G, c.B, c.A)]
-	}
-	return
+// Strings prefixed with # are parsed per the CSS spec, and may have 3, 4, 6,
+// or 8 digits (#rgb, #rgba, #rrggbb, or #rrggbbaa). Strings prefixed with 0x
+// are integer-style hex, as used by Android resources and Qt, and may have 6
+// or 8 digits (0xrrggbb or 0xaarrggbb). Bare hex strings may have 6 or 8
+// digits (rrggbb or rrggbbaa). See [WithByteOrder] for parsing other byte
+// orders.
+func FromWeb(s string) (Color, bool) {
+	c, err := parseHexLiteral(s, nil)
+	return c, err == nil
 }
 
 // FromName converts a name to a color.
+//
+// Names are case-insensitive, and ignore whitespace, punctuation, and any
+// leading digits, such that Misty_Rose, misty-rose, and MISTY ROSE are all
+// equivalent to mistyrose. Names are looked up in the [DefaultRegistry]. See
+// [Registry.FromName].
+//
+// Localized names, such as rot, 赤, or azul, are found once their locale is
+// registered. See [RegisterLocale] and [LookupLocale].
 func FromName(s string) (Color, bool) {
-	n := strings.ToLower(strings.TrimSpace(strcase.ForceCamelIdentifier(s)))
-	if c, ok := colors[NamedColor(n)]; ok {
-		return ToColor(c.R, c.G, c.B, c.A, n), true
-	}
-	return Color{}, false
+	return DefaultRegistry.FromName(s)
 }
 
-// FromRGB converts a rgb string to a color.
+// FromRGB converts a rgb string to a color, ex: rgb(255, 0, 0) or
+// rgb(100% 0% 0% / 50%).
+//
+// Parses both the legacy (comma separated) and modern (space separated)
+// syntaxes defined in the CSS Color Level 4 spec. Channels may be numbers
+// (0-255) or percentages, and an optional alpha may be a number (0-1) or a
+// percentage. Out of range values are clamped, and the none keyword is
+// treated as 0.
 func FromRGB(s string) (Color, bool) {
-	return fromRE(s, rgbRE, parseDec, false)
+	c, err := options{}.parseRGB(s, "rgb")
+	return c, err == nil
 }
 
-// FromRGBA converts a rgba string to a color.
+// FromRGBA converts a rgba string to a color, ex: rgba(255, 0, 0, 0.5).
+//
+// See [FromRGB] for the supported syntax.
 func FromRGBA(s string) (Color, bool) {
-	return fromRE(s, rgbaRE, parseDec, false)
+	c, err := options{}.parseRGB(s, "rgba")
+	return c, err == nil
 }
 
-// FromHex converts a hex string to a color.
+// FromHex converts a hex string to a color, ex: hex(ff, e4, e1) or hex(ff,
+// e4, e1, 80). Each channel has 1 or 2 hex digits.
 func FromHex(s string) (Color, bool) {
-	return fromRE(s, hexRE, parseHex, true)
+	c, err := parseHexFunc(s)
+	return c, err == nil
+}
+
+// parseHexFunc parses a hex string.
+func parseHexFunc(s string) (Color, *ParseError) {
+	f, err := parseNamedFunc(s, "hex")
+	switch {
+	case err != nil:
+		return Color{}, err
+	case !f.legacy:
+		return Color{}, errorf(len("hex("), "expected comma separated channels")
+	case f.n != 3:
+		return Color{}, errorf(len("hex("), "expected 3 or 4 channels, got %d", f.n)
+	}
+	args := [4]string{f.args[0], f.args[1], f.args[2], f.alpha}
+	offs := [4]int{f.offs[0], f.offs[1], f.offs[2], f.alphaOff}
+	v := [4]uint8{3: 0xff}
+	for i, arg := range args {
+		if arg == "" {
+			continue
+		}
+		var ok bool
+		if v[i], ok = parseHex(arg); !ok {
+			if _, err := strconv.ParseUint(arg, 16, 64); err == nil {
+				return Color{}, errorf(offs[i], "channel %d out of range 00-ff", i+1)
+			}
+			return Color{}, errorf(offs[i], "invalid channel %d %q", i+1, arg)
+		}
+	}
+	return New(v[0], v[1], v[2], v[3]), nil
 }
 
 // UnmarshalText satisfies the [encoding.TextUnmarshaler] interface.
 func (c *Color) UnmarshalText(text []byte) error {
 	var err error
-	*c, err = Parse(string(text))
+	*c, err = DefaultParser.ParseBytes(text)
 	return err
 }
 
@@ -241,6 +276,12 @@ func (c Color) Format(f fmt.State, verb rune) {
 		_, _ = f.Write([]byte(c.Name()))
 	case 'e':
 		_, _ = f.Write([]byte(c.AsWeb()))
+	case 'h':
+		if c.A != 0xff {
+			_, _ = f.Write([]byte(c.AsHSLA()))
+		} else {
+			_, _ = f.Write([]byte(c.AsHSL()))
+		}
 	}
 }
 
@@ -258,10 +299,22 @@ func (c Color) AsRGB() string {
 }
 
 // AsRGBA returns the color formatted as a rgba string, ex: rgba(255,255,255,255).
+//
+// The alpha is 0-255, and is parsed using [WithLegacyAlpha]. See
+// [Color.AsCSSRGB] for the CSS form.
 func (c Color) AsRGBA() string {
 	return fmt.Sprintf("rgba(%d,%d,%d,%d)", c.R, c.G, c.B, c.A)
 }
 
+// AsCSSRGB returns the color formatted as a CSS Color Level 4 rgb string,
+// ex: rgb(255 255 255 / 0.5). The alpha is omitted when the color is opaque.
+func (c Color) AsCSSRGB() string {
+	if c.A != 0xff {
+		return fmt.Sprintf("rgb(%d %d %d / %s)", c.R, c.G, c.B, formatFloat(float64(c.A)/0xff, 3))
+	}
+	return fmt.Sprintf("rgb(%d %d %d)", c.R, c.G, c.B)
+}
+
 // AsHex returns the color formatted as a web, ex: hex(aa,bb,cc,dd).
 func (c Color) AsHex() string {
 	if c.A != 0xff {
@@ -296,12 +349,12 @@ func (Color) Type() string {
 
 // Pflag returns a [Pflag] wrapping the color, that can be used with various
 // command-line packages, such as [cobra], and satisfies the [pflag.Value]
-// interface.
+// interface. See [Parser.Pflag] for using a custom parser.
 //
 // [cobra]: https://github.com/spf13/cobra
 // [pflag.Value]: https://pkg.go.dev/github.com/spf13/pflag#Value
 func (c *Color) Pflag() Pflag {
-	return Pflag{c}
+	return Pflag{c, nil}
 }
 
 // Pflag wraps a color, for use with command-line packages, such as [cobra].
@@ -311,6 +364,7 @@ func (c *Color) Pflag() Pflag {
 // [pflag.Value]: https://pkg.go.dev/github.com/spf13/pflag#Value
 type Pflag struct {
 	c *Color
+	p *Parser
 }
 
 // String satisfies the [pflag.Value] interface.
@@ -324,8 +378,12 @@ func (f Pflag) String() string {
 //
 // [pflag.Value]: https://pkg.go.dev/github.com/spf13/pflag#Value
 func (f Pflag) Set(s string) error {
+	p := f.p
+	if p == nil {
+		p = DefaultParser
+	}
 	var err error
-	*f.c, err = Parse(s)
+	*f.c, err = p.Parse(s)
 	return err
 }
 
@@ -336,6 +394,23 @@ func (Pflag) Type() string {
 	return "color"
 }
 
+// UnmarshalText satisfies the [encoding.TextUnmarshaler] interface, parsing
+// the color with the Pflag's parser.
+func (f Pflag) UnmarshalText(text []byte) error {
+	p := f.p
+	if p == nil {
+		p = DefaultParser
+	}
+	var err error
+	*f.c, err = p.ParseBytes(text)
+	return err
+}
+
+// MarshalText satisfies the [encoding.TextMarshaler] interface.
+func (f Pflag) MarshalText() ([]byte, error) {
+	return f.c.MarshalText()
+}
+
 // Is returns true when a, b are equivalent.
 func Is(a, b color.Color) bool {
 	i := color.NRGBAModel.Convert(a).(color.NRGBA)
@@ -346,68 +421,74 @@ func Is(a, b color.Color) bool {
 		i.A == j.A
 }
 
-// Error is a error.
-type Error string
-
-// Error satisfies the [error] interface.
-func (err Error) Error() string {
-	return string(err)
-}
-
-// Errors.
-const (
-	// ErrInvalidColor is invalid color error.
-	ErrInvalidColor Error = "invalid color"
-)
-
-// fromRE parses all regexp matches with f.
-func fromRE(s string, re *regexp.Regexp, f func(string) (uint8, bool), lastOptional bool) (Color, bool) {
-	m := re.FindStringSubmatch(s)
-	n := len(m)
-	if n != 4 && n != 5 {
-		return Color{}, false
+// parseRGB parses a rgb or rgba string for the named function.
+func (o options) parseRGB(s, name string) (Color, *ParseError) {
+	f, err := parseNamedFunc(s, name)
+	if err != nil {
+		return Color{}, err
 	}
-	var c Color
-	var ok bool
-	if c.R, ok = f(m[1]); !ok {
-		return Color{}, false
+	if err := f.expect(3, true); err != nil {
+		return Color{}, err
 	}
-	if c.G, ok = f(m[2]); !ok {
-		return Color{}, false
+	c := channel{0xff, 0, 0xff}
+	v, err := o.parseChannels(f, [3]channel{c, c, c})
+	if err != nil {
+		return Color{}, err
 	}
-	if c.B, ok = f(m[3]); !ok {
-		return Color{}, false
+	// legacy syntax does not allow mixing numbers and percentages
+	if x, y, z := isPercent(f.args[0]), isPercent(f.args[1]), isPercent(f.args[2]); f.legacy && (x != y || y != z) {
+		return Color{}, errorf(len(name)+1, "cannot mix numbers and percentages in legacy syntax")
 	}
-	switch {
-	case !lastOptional && n == 5 && m[4] == "":
-		return Color{}, false
-	case n == 5 && m[4] != "":
-		if c.A, ok = f(m[4]); !ok {
-			return Color{}, false
-		}
-	default:
-		c.A = 0xff
+	a, err := o.parseAlpha(f, o.legacyAlpha)
+	if err != nil {
+		return Color{}, err
 	}
-	c.NamedColor = lookup[mapKey(c.R, c.G, c.B, c.A)]
-	return c, true
+	return New(toUint8(v[0]/0xff), toUint8(v[1]/0xff), toUint8(v[2]/0xff), toUint8(a)), nil
 }
 
-// parseDec parses a decimal number in s.
-func parseDec(s string) (uint8, bool) {
-	u, err := strconv.ParseUint(s, 10, 8)
-	return uint8(u), err == nil
+// isPercent returns true when s is a CSS <percentage>.
+func isPercent(s string) bool {
+	return strings.HasSuffix(s, "%")
 }
 
-// parseHex parses a hex number in s.
+// parseHex parses a 1 or 2 digit hex number in s.
 func parseHex(s string) (uint8, bool) {
-	u, err := strconv.ParseUint(s, 16, 8)
-	return uint8(u), err == nil
+	var v uint8
+	for i := 0; i < len(s); i++ {
+		d, ok := hexDigit(s[i])
+		if !ok || i == 2 {
+			return 0, false
+		}
+		v = v<<4 | d
+	}
+	return v, len(s) != 0
 }
 
-// regexps.
-var (
-	rgbRE  = regexp.MustCompile(`(?i)^rgb\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)$`)
-	rgbaRE = regexp.MustCompile(`(?i)^rgba\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)$`)
-	hexRE  = regexp.MustCompile(`(?i)^hex\(\s*([0-9a-f]{1,2})\s*,\s*([0-9a-f]{1,2})\s*,\s*([0-9a-f]{1,2})\s*(?:,\s*([0-9a-f]{1,2})\s*)?\)$`)
-	// webRE  = regexp.MustCompile(`(?i)^#([0-9a-f]{2})([0-9a-f]{2})([0-9a-f]{2})([0-9a-f]{2})?$`)
-)
+// clamp clamps v to lo, hi.
+func clamp(v, lo, hi float64) float64 {
+	return max(lo, min(v, hi))
+}
+
+// toUint8 converts v (0-1) to a uint8. NaN is treated as 0.
+func toUint8(v float64) uint8 {
+	return uint8(math.Round(clamp(noneToZero(v), 0, 1) * 0xff))
+}
+
+// noneToZero returns 0 when v is NaN (a CSS none value), or v.
+func noneToZero(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return v
+}
+
+// formatFloat formats v, rounded to prec decimal places, without trailing
+// zeros.
+func formatFloat(v float64, prec int) string {
+	p := math.Pow(10, float64(prec))
+	v = math.Round(v*p) / p
+	if v == 0 {
+		v = 0 // avoid -0
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -1,8 +1,10 @@
 package colors
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,6 +27,9 @@ func TestParse(t *testing.T) {
 			"hex(00,00,00,ff)",
 			"#000000",
 			"#000000ff",
+			"hsl(0,0%,0%)",
+			"hsla(0deg,0%,0%,1)",
+			"hwb(0 0% 100%)",
 		}},
 		{"white", color.White, []string{
 			"white",
@@ -34,6 +39,10 @@ func TestParse(t *testing.T) {
 			"hex( ff , ff, ff, ff)",
 			"#ffffff",
 			"#ffffffff",
+			"hsl(0, 0%, 100%)",
+			"hsla(0.5turn, 0%, 100%, 100%)",
+			"hwb(0 100% 0%)",
+			"hwb(90 100 0 / 1)",
 		}},
 		{"red", Red, []string{
 			"red",
@@ -43,6 +52,13 @@ func TestParse(t *testing.T) {
 			"hex(ff,0,0,ff)",
 			"#ff0000",
 			"#ff0000ff",
+			"hsl(0,100%,50%)",
+			"hsl(360,100%,50%)",
+			"hsl(-360deg,100%,50%)",
+			"hsl(400grad,100%,50%)",
+			"hsla(0,100%,50%,1.0)",
+			"hwb(0 0% 0%)",
+			"hwb(none none none)",
 		}},
 		{"lime", Lime, []string{
 			"lime",
@@ -52,6 +68,10 @@ func TestParse(t *testing.T) {
 			"hex(0,ff,0,ff)",
 			"#00ff00",
 			"#00ff00ff",
+			"hsl(120,100%,50%)",
+			"hsl(-240,100%,50%)",
+			"hsl(0.3333333333turn,100%,50%)",
+			"hsla(2.0943951024rad,100%,50%,1)",
 		}},
 		{"blue", Blue, []string{
 			"BLUE",
@@ -61,6 +81,8 @@ func TestParse(t *testing.T) {
 			"HEX(0,0,FF,FF)",
 			"#0000FF",
 			"#0000FFFF",
+			"HSL(240,100%,50%)",
+			"HSLA(240DEG,100%,50%,1)",
 		}},
 		{"mistyrose", Mistyrose, []string{ // {0xff, 0xe4, 0xe1, 0xff} rgb(255, 228, 225)
 			"  Misty_Rose  ",
@@ -70,6 +92,19 @@ func TestParse(t *testing.T) {
 			"  hex(ff,  e4,e1,ff)",
 			"#ffe4e1   ",
 			"  #ffe4e1ff  ",
+			"0xffe4e1",
+			"0XFFFFE4E1",
+			"FFE4E1",
+			"ffe4e1ff",
+			"rgb:ffff/e4e4/e1e1",
+			"RGB:f/e4/e1e",
+			"#fffe4ee1e",
+			"#ffffe4e4e1e1",
+			"hsl(6, 100%, 94.12%)",
+			" hsla( 6deg , 100% , 94.12% , 1 ) ",
+			"hwb(6 88.24% 0%)",
+			"rgb(from Misty_Rose r g b)",
+			" hsl(from #ffe4e1 h s l) ",
 		}},
 		{"indianred", Indianred, []string{ // {0xcd, 0x5c, 0x5c, 0xff} rgb(205, 92, 92)
 			"indIAN_red",
@@ -79,6 +114,8 @@ func TestParse(t *testing.T) {
 			"Hex(cd,5c,5c,fF)",
 			"#CD5c5c",
 			"#CD5c5cfF",
+			"Hsl(0,53.05%,58.24%)",
+			"Hsla(0,53.05%,58.24%,100%)",
 		}},
 	}
 	m := allColors(false)
@@ -93,6 +130,13 @@ func TestParse(t *testing.T) {
 			key[strings.LastIndexByte(key, '/')+1:],
 			fmt.Sprintf("rgba( %d, %d, %d, %d )", c.R, c.G, c.B, c.A),
 			fmt.Sprintf("hex( %x, %x, %x, %x )", c.R, c.G, c.B, c.A),
+			FromColor(c).AsHSLA(),
+			FromColor(c).AsHWB(),
+			FromColor(c).AsOKLCH(),
+			FromColor(c).AsCSSRGB(),
+		}
+		if c.A == 0xff {
+			v = append(v, FromColor(c).AsX11())
 		}
 		tests = append(tests, struct {
 			name string
@@ -137,12 +181,26 @@ func testParse(t *testing.T, name, s string, exp color.Color) {
 		n, f = "AsRGBA", c.AsRGBA
 	case strings.Contains(z, "hex("):
 		n, f = "AsHex", c.AsHex
+	case strings.Contains(z, "hsl("):
+		n, f = "AsHSL", c.AsHSL
+	case strings.Contains(z, "hsla("):
+		n, f = "AsHSLA", c.AsHSLA
+	case strings.Contains(z, "hwb("):
+		n, f = "AsHWB", c.AsHWB
+	case strings.Contains(z, "rgb:"):
+		n, f = "AsX11", c.AsX11
+	case strings.Contains(z, "oklch("):
+		n, f = "AsOKLCH", c.AsOKLCH
 	case strings.Contains(z, "#"):
 		n, f = "AsWeb", c.AsWeb
 	case isNamedColor(s):
 		n, f = "NamedColor", func() string {
 			return string(c.NamedColor)
 		}
+	case strings.Trim(z, "0123456789abcdefx ") == "":
+		n, f = "Uint32", func() string {
+			return fmt.Sprintf("%08x", c.Uint32(OrderRGBA))
+		}
 	default:
 		t.Fatalf("invalid test %q", s)
 	}
@@ -158,8 +216,6 @@ func TestBad(t *testing.T) {
 		"rgb(0)",
 		"rgb(0,0)",
 		"rgb(0,0,a)",
-		"rgb(256,256,256)",
-		"rgb(100,100,100,50)",
 		"rgb(,,)",
 		"rgba()",
 		"rgba(0)",
@@ -167,7 +223,6 @@ func TestBad(t *testing.T) {
 		"rgba(0,0,a)",
 		"rgba(0,0,0,a)",
 		"rgba(,,,)",
-		"rgba(256,256,256,256)",
 		"rgba(100,100,100,)",
 		"hex()",
 		"hex(0)",
@@ -178,17 +233,287 @@ func TestBad(t *testing.T) {
 		"#a",
 		"#ab",
 		"#coo",
-		"#bada",
+		"#abcde",
+		"#abcdefa",
+		"#abcdefabcd",
+		"rgb:0/0",
+		"rgbi:2/0/0",
+		"0x",
+		"0xabc",
+		"0xabcd",
+		"0xabcdefa",
+		"0xabcdefgh",
+		"abc",
+		"abcd",
+		"abcde",
+		"abcdefg",
+		"ffe4e1z",
 		"#coolao",
+		"rgb(0 0 0,)",
+		"rgb(0,0 0)",
+		"rgb(0 0 0 /)",
+		"rgb(0 0 0 / 1 / 1)",
+		"rgb(0 0 0 / 1 1)",
+		"rgb(0,0,0/1)",
+		"rgb(0 0)",
+		"rgb(0 0 0 0)",
+		"rgb(0,0%,0)",
+		"rgb(none,0,0)",
+		"rgb(0,0,0,none)",
+		"rgb(0deg 0 0)",
+		"rgb(0 0 0 / 1deg)",
+		"rgb(0 0 0))",
+		"rgb((0 0 0)",
+		"hsl()",
+		"hsl(0)",
+		"hsl(0,0%)",
+		"hsl(0,0,0)",
+		"hsl(0,0%,0%,)",
+		"hsl(0foo,0%,0%)",
+		"hsl(a,0%,0%)",
+		"hsla(0,0%,0%,a)",
+		"hwb()",
+		"hwb(0 0%)",
+		"hwb(0, 0%, 0%)",
+		"hwb(0 0% 0% 0%)",
+		"hwb(0 0deg 0%)",
+		"hwb(0 0% 0% / a)",
 		"__",
 	}
 	for i, s := range tests {
-		if _, err := Parse(s); err == nil {
+		_, err := Parse(s)
+		var pe *ParseError
+		switch {
+		case err == nil:
 			t.Errorf("test %d %q expected error", i, s)
+		case !errors.Is(err, ErrInvalidColor):
+			t.Errorf("test %d %q expected ErrInvalidColor, got: %v", i, s, err)
+		case !errors.As(err, &pe):
+			t.Errorf("test %d %q expected *ParseError, got: %T", i, s, err)
+		case pe.Input != s || pe.Offset < 0 || pe.Offset > len(s) || pe.Reason == "":
+			t.Errorf("test %d %q invalid parse error: %#v", i, s, pe)
 		}
 	}
 }
 
+func TestParseCSS(t *testing.T) {
+	tests := []struct {
+		s    string
+		opts []Option
+		exp  color.NRGBA
+		err  bool
+	}{
+		{"rgb(255 0 0)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"rgb(255 0 0 / 50%)", nil, color.NRGBA{255, 0, 0, 128}, false},
+		{"rgb(255 0 0 / .5)", nil, color.NRGBA{255, 0, 0, 128}, false},
+		{"rgb(100% 0% 0%)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"rgb(100% 0 50%)", nil, color.NRGBA{255, 0, 128, 255}, false},
+		{"rgb(100%, 0%, 50%)", nil, color.NRGBA{255, 0, 128, 255}, false},
+		{"rgb(none 255 none)", nil, color.NRGBA{0, 255, 0, 255}, false},
+		{"rgb(0 0 0 / none)", nil, color.NRGBA{0, 0, 0, 0}, false},
+		{"rgb(127.5 0 0)", nil, color.NRGBA{128, 0, 0, 255}, false},
+		{"rgb(2.55e2 0 0)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"rgb(256, 256, 256)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"rgb(-10, 300, 120%)", nil, color.NRGBA{}, true},
+		{"rgb(-10 300 120%)", nil, color.NRGBA{0, 255, 255, 255}, false},
+		{"rgb(100,100,100,50)", nil, color.NRGBA{100, 100, 100, 255}, false},
+		{"rgb(100,100,100,-1)", nil, color.NRGBA{100, 100, 100, 0}, false},
+		{"rgba(26, 33, 80, 0.08)", nil, color.NRGBA{26, 33, 80, 20}, false},
+		{"rgba(26, 33, 80, 8%)", nil, color.NRGBA{26, 33, 80, 20}, false},
+		{"rgba(26 33 80)", nil, color.NRGBA{26, 33, 80, 255}, false},
+		{"rgba(256, 256, 256, 256)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"rgba(26, 33, 80, 22)", nil, color.NRGBA{26, 33, 80, 255}, false},
+		{"rgba(26, 33, 80, 22)", []Option{WithLegacyAlpha(true)}, color.NRGBA{26, 33, 80, 22}, false},
+		{"rgba(26 33 80 / 22)", []Option{WithLegacyAlpha(true)}, color.NRGBA{26, 33, 80, 22}, false},
+		{"rgba(26, 33, 80, 50%)", []Option{WithLegacyAlpha(true)}, color.NRGBA{26, 33, 80, 128}, false},
+		{"hsl(120deg 100% 50%)", nil, color.NRGBA{0, 255, 0, 255}, false},
+		{"hsl(120 100 50 / 0.5)", nil, color.NRGBA{0, 255, 0, 128}, false},
+		{"hsl(none 0% 100%)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"hsl(120, 200%, 50%)", nil, color.NRGBA{0, 255, 0, 255}, false},
+		{"hwb(120 0% 0%)", nil, color.NRGBA{0, 255, 0, 255}, false},
+		{"hwb(120 50% 50%)", nil, color.NRGBA{128, 128, 128, 255}, false},
+		{"hwb(120 80% 40%)", nil, color.NRGBA{170, 170, 170, 255}, false},
+		{"hwb(120 -10% 120%)", nil, color.NRGBA{0, 0, 0, 255}, false},
+		{"hwb(1turn 20% 20% / 25%)", nil, color.NRGBA{204, 51, 51, 64}, false},
+		{"lab(54.29 80.8 69.89)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"lab(54.29% 64.64% 55.91% / 50%)", nil, color.NRGBA{255, 0, 0, 128}, false},
+		{"lab(100 0 0)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"lab(150 0 0)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"lab(none none none)", nil, color.NRGBA{0, 0, 0, 255}, false},
+		{"lab(50 200 0)", nil, color.NRGBA{255, 0, 137, 255}, false},
+		{"lab(50, 0, 0)", nil, color.NRGBA{}, true},
+		{"lab(50 0deg 0)", nil, color.NRGBA{}, true},
+		{"lch(54.29 106.84 40.85)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"lch(54.29% 71.23% 0.1135turn / 0.5)", nil, color.NRGBA{255, 0, 0, 128}, false},
+		{"lch(100 0 none)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"lch(50 -10 0)", nil, color.NRGBA{119, 119, 119, 255}, false},
+		{"lch(50, 0, 0)", nil, color.NRGBA{}, true},
+		{"oklab(0.628 0.2249 0.1258)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"oklab(62.8% 56.22% 31.46% / 0.5)", nil, color.NRGBA{255, 0, 0, 128}, false},
+		{"oklab(1 0 0)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"oklab(150% none none)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"oklab(0.5, 0, 0)", nil, color.NRGBA{}, true},
+		{"oklch(0.628 0.2577 29.23)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"oklch(62.8% 64.42% 29.23deg / 50%)", nil, color.NRGBA{255, 0, 0, 128}, false},
+		{"oklch(0.452 0.3132 264.05)", nil, color.NRGBA{0, 0, 255, 255}, false},
+		{"oklch(0.8664 0.2948 142.5)", nil, color.NRGBA{0, 255, 0, 255}, false},
+		{"oklch(0% 0 none)", nil, color.NRGBA{0, 0, 0, 255}, false},
+		{"oklch(0.5 0 0 0)", nil, color.NRGBA{}, true},
+		{"color(srgb 1 0 0)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(srgb 100% 0% 50% / 50%)", nil, color.NRGBA{255, 0, 128, 128}, false},
+		{"color(srgb-linear 0.2159 0 0)", nil, color.NRGBA{128, 0, 0, 255}, false},
+		{"color(display-p3 0.9175 0.2003 0.1386)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(a98-rgb 0.8586 0 0)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(prophoto-rgb 0.7022 0.2757 0.1036)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(rec2020 0.792 0.231 0.0738)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(xyz 0.4124 0.2126 0.0193)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(xyz-d65 0.4124 0.2126 0.0193)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(xyz-d50 0.4361 0.2225 0.0139)", nil, color.NRGBA{255, 0, 0, 255}, false},
+		{"color(display-p3 none 1 none)", nil, color.NRGBA{0, 251, 41, 255}, false},
+		{"color(display-p3 2 2 2)", nil, color.NRGBA{255, 255, 255, 255}, false},
+		{"color(srgb 0 0)", nil, color.NRGBA{}, true},
+		{"color(srgb, 0, 0, 0)", nil, color.NRGBA{}, true},
+		{"color(hsl 0 0 0)", nil, color.NRGBA{}, true},
+		{"color(oklab 0 0 0)", nil, color.NRGBA{}, true},
+		{"color(unknown 0 0 0)", nil, color.NRGBA{}, true},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			c, err := Parse(test.s, test.opts...)
+			switch {
+			case test.err && err == nil:
+				t.Fatalf("expected error, got: %v", c)
+			case test.err:
+			case err != nil:
+				t.Fatalf("expected no error, got: %v", err)
+			case !c.Is(test.exp):
+				t.Errorf("%q expected %v, got: %#v", test.s, test.exp, c)
+			}
+		})
+	}
+}
+
+func TestHWB(t *testing.T) {
+	tests := []struct {
+		v   HWB
+		exp HWB
+	}{
+		{HWB{0, 0, 0, 1}, HWB{0, 0, 0, 1}},
+		{HWB{-90, 0.2, 0.3, 1}, HWB{270, 0.2, 0.3, 1}},
+		{HWB{720, 0.8, 0.4, 0.5}, HWB{0, 0.8 / 1.2, 0.4 / 1.2, 0.5}},
+		{HWB{120, 1, 1, 1}, HWB{120, 0.5, 0.5, 1}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if v := test.v.Normalize(); v != test.exp {
+				t.Errorf("expected %v, got: %v", test.exp, v)
+			}
+		})
+	}
+	for k, v := range MapString() {
+		t.Run(k, func(t *testing.T) {
+			if c := v.HWB().Color(); !c.Is(v) {
+				t.Errorf("expected %v, got: %v", v, c)
+			}
+		})
+	}
+}
+
+func TestLab(t *testing.T) {
+	tests := []struct {
+		c   Color
+		lab Lab
+		lch LCh
+	}{
+		{Black.Color(), Lab{0, 0, 0, 1}, LCh{0, 0, 0, 1}},
+		{White.Color(), Lab{100, 0, 0, 1}, LCh{100, 0, 0, 1}},
+		{Red.Color(), Lab{54.29, 80.8, 69.89, 1}, LCh{54.29, 106.84, 40.85, 1}},
+		{Lime.Color(), Lab{87.82, -79.27, 80.99, 1}, LCh{87.82, 113.33, 134.38, 1}},
+		{Blue.Color(), Lab{29.57, 68.29, -112.03, 1}, LCh{29.57, 131.2, 301.36, 1}},
+		{New(0x33, 0x66, 0x99, 0x80), Lab{41.52, -4.57, -33.49, 0.502}, LCh{41.52, 33.8, 262.23, 0.502}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			lab, lch := test.c.Lab(), test.c.LCh()
+			if !near(0.01, lab.L, test.lab.L, lab.A, test.lab.A, lab.B, test.lab.B, lab.Alpha, test.lab.Alpha) {
+				t.Errorf("expected %v, got: %v", test.lab, lab)
+			}
+			if !near(0.01, lch.L, test.lch.L, lch.C, test.lch.C, lch.Alpha, test.lch.Alpha) || (lch.C > 0.01 && !near(0.01, lch.H, test.lch.H)) {
+				t.Errorf("expected %v, got: %v", test.lch, lch)
+			}
+		})
+	}
+	for k, v := range MapString() {
+		t.Run(k, func(t *testing.T) {
+			c := FromColor(v)
+			switch a, ok := c.Lab().Color(); {
+			case !ok:
+				t.Errorf("expected %v to be in gamut", c)
+			case !a.Is(c):
+				t.Errorf("expected %v, got: %v", c, a)
+			}
+			switch b, ok := c.LCh().Color(); {
+			case !ok:
+				t.Errorf("expected %v to be in gamut", c)
+			case !b.Is(c):
+				t.Errorf("expected %v, got: %v", c, b)
+			}
+		})
+	}
+	if _, ok := (Lab{50, 200, 0, 1}).Color(); ok {
+		t.Errorf("expected lab(50 200 0) to be out of gamut")
+	}
+	if _, ok := (LCh{90, 150, 120, 1}).Color(); ok {
+		t.Errorf("expected lch(90 150 120) to be out of gamut")
+	}
+}
+
+func TestOKLab(t *testing.T) {
+	// reference values from the CSS Color Level 4 spec's sample code
+	tests := []struct {
+		c     Color
+		oklab OKLab
+		oklch OKLCH
+	}{
+		{Black.Color(), OKLab{0, 0, 0, 1}, OKLCH{0, 0, 0, 1}},
+		{White.Color(), OKLab{1, 0, 0, 1}, OKLCH{1, 0, 0, 1}},
+		{Red.Color(), OKLab{0.62796, 0.22486, 0.12585, 1}, OKLCH{0.62796, 0.25768, 29.2339, 1}},
+		{Lime.Color(), OKLab{0.86644, -0.23389, 0.1795, 1}, OKLCH{0.86644, 0.29483, 142.4953, 1}},
+		{Blue.Color(), OKLab{0.45201, -0.03246, -0.31153, 1}, OKLCH{0.45201, 0.31321, 264.052, 1}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			oklab, oklch := test.c.OKLab(), test.c.OKLCH()
+			if !near(0.0001, oklab.L, test.oklab.L, oklab.A, test.oklab.A, oklab.B, test.oklab.B, oklab.Alpha, test.oklab.Alpha) {
+				t.Errorf("expected %v, got: %v", test.oklab, oklab)
+			}
+			if !near(0.0001, oklch.L, test.oklch.L, oklch.C, test.oklch.C, oklch.Alpha, test.oklch.Alpha) || (oklch.C > 0.0001 && !near(0.001, oklch.H, test.oklch.H)) {
+				t.Errorf("expected %v, got: %v", test.oklch, oklch)
+			}
+		})
+	}
+	for k, v := range MapString() {
+		t.Run(k, func(t *testing.T) {
+			c := FromColor(v)
+			switch a, ok := c.OKLab().Color(); {
+			case !ok:
+				t.Errorf("expected %v to be in gamut", c)
+			case !a.Is(c):
+				t.Errorf("expected %v, got: %v", c, a)
+			}
+			switch b, ok := c.OKLCH().Color(); {
+			case !ok:
+				t.Errorf("expected %v to be in gamut", c)
+			case !b.Is(c):
+				t.Errorf("expected %v, got: %v", c, b)
+			}
+		})
+	}
+	if _, ok := (OKLCH{0.9, 0.4, 120, 1}).Color(); ok {
+		t.Errorf("expected oklch(0.9 0.4 120) to be out of gamut")
+	}
+}
+
 func TestInverse(t *testing.T) {
 	m := allColors(true)
 	var keys []string
@@ -218,6 +543,12 @@ func testInverse(t *testing.T, exp Color) {
 		exp.AsHex,
 		exp.Name,
 		exp.AsWeb,
+		exp.AsHSL,
+		exp.AsHSLA,
+		exp.AsHWB,
+		exp.AsOKLCH,
+		exp.AsX11,
+		exp.AsCSSRGB,
 	}
 	for i, f := range tests {
 		s := f()
@@ -225,14 +556,17 @@ func testInverse(t *testing.T, exp Color) {
 		switch {
 		case i < 2:
 			continue
-		case s == "" || (i == 2 && exp.A != 0xff):
+		case s == "" || ((i == 2 || i == 7 || i == 11) && exp.A != 0xff):
 			t.Log("  skipping")
 			continue
+		case i == 3:
+			check(t, s, exp, WithLegacyAlpha(true))
+			continue
 		}
 		check(t, s, exp)
 	}
 	t.Log("--")
-	for _, verb := range []rune{'s', 'v', 'd', 'a', 'x', 'n', 'e'} {
+	for _, verb := range []rune{'s', 'v', 'd', 'a', 'x', 'n', 'e', 'h'} {
 		s := fmt.Sprintf("%"+string(verb), exp)
 		switch {
 		case verb == 'd' && exp.A != 0xff:
@@ -243,6 +577,10 @@ func testInverse(t *testing.T, exp Color) {
 			continue
 		}
 		t.Logf("%c: %q", verb, s)
+		if verb == 'a' {
+			check(t, s, exp, WithLegacyAlpha(true))
+			continue
+		}
 		check(t, s, exp)
 	}
 }
@@ -288,8 +626,8 @@ func allColors(opaque bool) map[string]color.Color {
 	return m
 }
 
-func check(t *testing.T, s string, exp Color) {
-	c, err := Parse(s)
+func check(t *testing.T, s string, exp Color, opts ...Option) {
+	c, err := Parse(s, opts...)
 	switch {
 	case err != nil:
 		t.Fatalf("expected no error, got: %v", err)
@@ -298,6 +636,17 @@ func check(t *testing.T, s string, exp Color) {
 	}
 }
 
+// near returns true when each pair of values in v are within eps of each
+// other.
+func near(eps float64, v ...float64) bool {
+	for i := 0; i < len(v); i += 2 {
+		if math.Abs(v[i]-v[i+1]) > eps {
+			return false
+		}
+	}
+	return true
+}
+
 func isNamedColor(s string) bool {
 	name := strings.ToLower(strcase.ForceCamelIdentifier(s))
 	_, ok := colors[NamedColor(name)]
@@ -0,0 +1,180 @@
+package colors
+
+import (
+	"image/color"
+	"strings"
+)
+
+// Scheme is a color scheme, used to resolve the CSS light-dark() function
+// and system colors.
+type Scheme int
+
+// Color schemes.
+const (
+	SchemeLight Scheme = iota
+	SchemeDark
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (scheme Scheme) String() string {
+	if scheme == SchemeDark {
+		return "dark"
+	}
+	return "light"
+}
+
+// Context is the context used to resolve context-dependent CSS colors: the
+// currentcolor keyword, system colors (ex: Canvas or AccentColor), and the
+// light-dark() function. See [WithContext].
+type Context struct {
+	// CurrentColor is the value of currentcolor. When nil, currentcolor is
+	// the CanvasText system color, the initial value of the CSS color
+	// property.
+	CurrentColor color.Color
+	// Scheme is the color scheme.
+	Scheme Scheme
+	// SystemColors are the system colors, keyed by their case-insensitive
+	// name (ex: CanvasText). When nil, the default system colors for the
+	// scheme are used. See [SystemColors].
+	SystemColors map[string]Color
+}
+
+// WithContext is a parse option to set the context used to resolve
+// currentcolor, system colors, and light-dark(), ex: light-dark(#fff, #000).
+//
+// Without a context, currentcolor and system colors are invalid, and
+// light-dark() is resolved using the light color scheme.
+func WithContext(ctx Context) Option {
+	return func(o *options) {
+		o.ctx = &ctx
+	}
+}
+
+// SystemColors returns a copy of the default CSS system colors for the color
+// scheme, for use as a starting point for a context's system colors. See
+// [Context].
+//
+// The defaults are based on the colors used by web browsers.
+//
+// See: https://www.w3.org/TR/css-color-4/#css-system-colors
+func SystemColors(scheme Scheme) map[string]Color {
+	m := make(map[string]Color, len(systemColors))
+	for _, c := range systemColors {
+		m[c.name] = c.color(scheme)
+	}
+	return m
+}
+
+// systemColor is a default CSS system color.
+type systemColor struct {
+	name        string
+	light, dark uint32
+}
+
+// color returns the system color for the scheme.
+func (c systemColor) color(scheme Scheme) Color {
+	if scheme == SchemeDark {
+		return FromUint32(c.dark, OrderRGBA)
+	}
+	return FromUint32(c.light, OrderRGBA)
+}
+
+// systemColors are the default CSS system colors, as rgba.
+var systemColors = []systemColor{
+	{"AccentColor", 0x0075ffff, 0x99c8ffff},
+	{"AccentColorText", 0xffffffff, 0x000000ff},
+	{"ActiveText", 0xff0000ff, 0xff9e9eff},
+	{"ButtonBorder", 0x767676ff, 0x6b6b6bff},
+	{"ButtonFace", 0xefefefff, 0x6b6b6bff},
+	{"ButtonText", 0x000000ff, 0xffffffff},
+	{"Canvas", 0xffffffff, 0x121212ff},
+	{"CanvasText", 0x000000ff, 0xffffffff},
+	{"Field", 0xffffffff, 0x3b3b3bff},
+	{"FieldText", 0x000000ff, 0xffffffff},
+	{"GrayText", 0x808080ff, 0x808080ff},
+	{"Highlight", 0x3399ffff, 0x3399ffff},
+	{"HighlightText", 0xffffffff, 0xffffffff},
+	{"LinkText", 0x0000eeff, 0x9e9effff},
+	{"Mark", 0xffff00ff, 0xffff00ff},
+	{"MarkText", 0x000000ff, 0x000000ff},
+	{"SelectedItem", 0x3399ffff, 0x3399ffff},
+	{"SelectedItemText", 0xffffffff, 0xffffffff},
+	{"VisitedText", 0x551a8bff, 0xd0adf0ff},
+}
+
+// systemColor returns the named system color from the context.
+func (ctx Context) systemColor(name string) (Color, bool) {
+	if ctx.SystemColors == nil {
+		for _, c := range systemColors {
+			if strings.EqualFold(c.name, name) {
+				return c.color(ctx.Scheme), true
+			}
+		}
+		return Color{}, false
+	}
+	if c, ok := ctx.SystemColors[name]; ok {
+		return c, true
+	}
+	for k, c := range ctx.SystemColors {
+		if strings.EqualFold(k, name) {
+			return c, true
+		}
+	}
+	return Color{}, false
+}
+
+// parseSystem parses a CSS system color. System colors are invalid without
+// a context.
+func (o options) parseSystem(s string) (Color, *ParseError) {
+	if o.ctx == nil {
+		if _, ok := (Context{}).systemColor(s); ok {
+			return Color{}, errorf(0, "system color %q requires a context", s)
+		}
+		return Color{}, errNoMatch
+	}
+	if c, ok := o.ctx.systemColor(s); ok {
+		return c, nil
+	}
+	return Color{}, errNoMatch
+}
+
+// parseCurrentColor parses the CSS currentcolor keyword. The currentcolor
+// keyword is invalid without a context.
+func (o options) parseCurrentColor(s string) (Color, *ParseError) {
+	switch {
+	case !strings.EqualFold(s, "currentcolor"):
+		return Color{}, errNoMatch
+	case o.ctx == nil:
+		return Color{}, errorf(0, "currentcolor requires a context")
+	case o.ctx.CurrentColor != nil:
+		return FromColor(o.ctx.CurrentColor), nil
+	}
+	return o.parseSystem("CanvasText")
+}
+
+// parseLightDark parses a CSS light-dark() function, ex: light-dark(#fff,
+// #000), returning the first color with the light color scheme, and the
+// second color otherwise.
+func (o options) parseLightDark(s string) (FloatColor, *ParseError) {
+	args, off, err := funcArgs(s, "light-dark")
+	if err != nil {
+		return FloatColor{}, err
+	}
+	var v [2]string
+	var offs [2]int
+	if n, ok := split(args, ',', v[:], offs[:]); !ok || n != 2 {
+		return FloatColor{}, errorf(off, "expected 2 colors")
+	}
+	light, err := o.parseFloat(v[0])
+	if err != nil {
+		return FloatColor{}, nested(err, off+offs[0])
+	}
+	dark, err := o.parseFloat(v[1])
+	if err != nil {
+		return FloatColor{}, nested(err, off+offs[1])
+	}
+	if o.ctx != nil && o.ctx.Scheme == SchemeDark {
+		return dark, nil
+	}
+	return light, nil
+}
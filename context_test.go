@@ -0,0 +1,105 @@
+package colors
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	light, dark := Context{}, Context{Scheme: SchemeDark}
+	custom := Context{
+		CurrentColor: color.NRGBA{0x12, 0x34, 0x56, 0xff},
+		SystemColors: map[string]Color{"canvas": New(0xfa, 0xfa, 0xfa, 0xff)},
+	}
+	tests := []struct {
+		s   string
+		ctx *Context
+		exp string
+	}{
+		{"currentcolor", &light, "#000000"},
+		{"CurrentColor", &dark, "#ffffff"},
+		{"currentColor", &custom, "#123456"},
+		{"Canvas", &light, "#ffffff"},
+		{"canvas", &dark, "#121212"},
+		{"CANVASTEXT", &dark, "#ffffff"},
+		{"AccentColor", &light, "#0075ff"},
+		{"LinkText", &dark, "#9e9eff"},
+		{"Canvas", &custom, "#fafafa"},
+		{"light-dark(#fff, #000)", nil, "#ffffff"},
+		{"light-dark(#fff, #000)", &dark, "#000000"},
+		{"Light-Dark(red, rgb(0 0 255 / 50%))", &dark, "#0000ff80"},
+		{"light-dark(Canvas, CanvasText)", &dark, "#ffffff"},
+		{"rgb(from currentcolor r g b / 50%)", &custom, "#12345680"},
+		{"color-mix(in srgb, currentcolor, white)", &custom, "#899aab"},
+		{"light-dark(light-dark(red, lime), blue)", nil, "#ff0000"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			var opts []Option
+			if test.ctx != nil {
+				opts = append(opts, WithContext(*test.ctx))
+			}
+			c, err := Parse(test.s, opts...)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if v := c.AsWeb(); v != test.exp {
+				t.Errorf("expected %s, got: %s", test.exp, v)
+			}
+		})
+	}
+}
+
+func TestContextBad(t *testing.T) {
+	custom := WithContext(Context{SystemColors: map[string]Color{"Canvas": New(0xfa, 0xfa, 0xfa, 0xff)}})
+	tests := []struct {
+		s      string
+		opts   []Option
+		offset int
+		reason string
+	}{
+		{"light-dark(#fff)", nil, 11, "expected 2 colors"},
+		{"light-dark(#fff, #000, #111)", nil, 11, "expected 2 colors"},
+		{"light-dark(#fff, #00)", nil, 17, "invalid length 2, expected 3, 4, 6, or 8 digits"},
+		{"CanvasText", []Option{custom}, 0, `unknown color name "CanvasText"`},
+		{"Canvas", nil, 0, `system color "Canvas" requires a context`},
+		{"currentcolor", nil, 0, "currentcolor requires a context"},
+		{"rgb(from currentColor r g b)", nil, 9, "currentcolor requires a context"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			_, err := Parse(test.s, test.opts...)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected *ParseError, got: %v", err)
+			}
+			if perr.Offset != test.offset || perr.Reason != test.reason {
+				t.Errorf("expected %d %q, got: %d %q", test.offset, test.reason, perr.Offset, perr.Reason)
+			}
+		})
+	}
+}
+
+func TestSystemColors(t *testing.T) {
+	for _, scheme := range []Scheme{SchemeLight, SchemeDark} {
+		m := SystemColors(scheme)
+		if len(m) != len(systemColors) {
+			t.Errorf("expected %d system colors, got: %d", len(systemColors), len(m))
+		}
+		for name, c := range m {
+			v, err := Parse(name, WithContext(Context{Scheme: scheme}))
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if v != c {
+				t.Errorf("%s %s: expected %v, got: %v", scheme, name, c, v)
+			}
+		}
+		// modifying the copy does not change the defaults
+		m["Canvas"] = Color{}
+		if c := SystemColors(scheme)["Canvas"]; c == (Color{}) {
+			t.Errorf("expected the defaults to be unchanged")
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package colors
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// cssFunc is a parsed CSS functional notation, ex: rgb(255 0 0 / 50%).
+type cssFunc struct {
+	// name is the function name.
+	name string
+	// args are the channel arguments, and offs are their offsets.
+	args [maxArgs]string
+	offs [maxArgs]int
+	// n is the number of channel arguments.
+	n int
+	// alpha is the alpha argument, if any, and alphaOff is its offset.
+	alpha    string
+	alphaOff int
+	// legacy is whether the arguments were comma separated (the CSS "legacy"
+	// syntax).
+	legacy bool
+}
+
+// maxArgs is the maximum number of arguments for a css function.
+const maxArgs = 8
+
+// parseNamedFunc parses a CSS functional notation in s when its name is
+// name, ex: rgb. Other functions are rejected with errNoMatch without
+// parsing their arguments. Offsets are relative to s.
+//
+// Arguments are either comma separated (legacy syntax), or whitespace
+// separated with an optional alpha following a slash (modern syntax). With
+// the legacy syntax, a 4th argument is treated as the alpha. Nested
+// parentheses are kept intact.
+func parseNamedFunc(s, name string) (cssFunc, *ParseError) {
+	var f cssFunc
+	s, off, err := funcArgs(s, name)
+	if err != nil {
+		return f, err
+	}
+	f.name = name
+	var toks [maxArgs + 2]string
+	var offs [maxArgs + 2]int
+	var n, slash, commas int
+	slash = -1
+	start, depth := -1, 0
+	flush := func(end int) bool {
+		if start != -1 {
+			if n == len(toks) {
+				return false
+			}
+			toks[n], offs[n], n, start = s[start:end], off+start, n+1, -1
+		}
+		return true
+	}
+	for j := 0; j < len(s); j++ {
+		switch c := s[j]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth != 0:
+		case c == ',', c == '/':
+			if !flush(j) {
+				return f, errorf(off, "too many arguments")
+			}
+			if c == '/' {
+				if slash != -1 || commas != 0 {
+					return f, errorf(off, "invalid argument separators")
+				}
+				slash = n
+				continue
+			}
+			// each comma must follow exactly one argument
+			if slash != -1 || n != commas+1 {
+				return f, errorf(off, "invalid argument separators")
+			}
+			commas++
+			continue
+		case isSpace(c):
+			if !flush(j) {
+				return f, errorf(off, "too many arguments")
+			}
+			continue
+		}
+		if start == -1 {
+			start = j
+		}
+	}
+	if !flush(len(s)) {
+		return f, errorf(off, "too many arguments")
+	}
+	switch {
+	case commas != 0:
+		// legacy syntax, ex: rgba(0, 0, 0, 0.5)
+		if n != commas+1 {
+			return f, errorf(off, "invalid argument separators")
+		}
+		if n > 4 {
+			return f, errorf(off, "too many arguments")
+		}
+		f.legacy = true
+		if n == 4 {
+			n, f.alpha, f.alphaOff = 3, toks[3], offs[3]
+		}
+	case slash != -1:
+		// modern syntax, ex: rgb(0 0 0 / 50%)
+		if slash != n-1 {
+			return f, errorf(off, "invalid argument separators")
+		}
+		n, f.alpha, f.alphaOff = n-1, toks[n-1], offs[n-1]
+	}
+	if n > maxArgs {
+		return f, errorf(off, "too many arguments")
+	}
+	f.n = copy(f.args[:], toks[:n])
+	copy(f.offs[:], offs[:n])
+	return f, nil
+}
+
+// funcArgs returns the arguments of the CSS functional notation in s when
+// its name is name, and the offset of the arguments in s, ex: "0 0 0" and 4
+// for rgb(0 0 0). Other functions are rejected with errNoMatch.
+func funcArgs(s, name string) (string, int, *ParseError) {
+	i := len(name)
+	if i == 0 || len(s) <= i || s[i] != '(' || !strings.EqualFold(s[:i], name) {
+		return "", 0, errNoMatch
+	}
+	depth, end := 0, -1
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '(':
+			depth++
+		case ')':
+			switch depth--; {
+			case depth < 0:
+				return "", 0, errorf(j, "unexpected closing parenthesis")
+			case depth == 0 && end == -1:
+				end = j
+			}
+		}
+	}
+	switch {
+	case depth != 0:
+		return "", 0, errorf(len(s), "missing closing parenthesis")
+	case end != len(s)-1:
+		return "", 0, errorf(end+1, "unexpected characters after closing parenthesis")
+	}
+	return s[i+1 : end], i + 1, nil
+}
+
+// funcName returns the name of the CSS functional notation in s, ex: rgb for
+// rgb(255 0 0), or an empty string when s is not a functional notation.
+func funcName(s string) string {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '(':
+			return s[:i]
+		case !isLetter(c) && c != '-':
+			return ""
+		}
+	}
+	return ""
+}
+
+// split splits s into out at each sep that is not nested within parentheses,
+// trimming whitespace from each part, and returning the number of parts. When
+// sep is a space, parts are separated by any amount of whitespace. When offs
+// is not nil, the offset of each part in s is stored in offs.
+func split(s string, sep byte, out []string, offs []int) (int, bool) {
+	var n, depth int
+	start := 0
+	add := func(end int) bool {
+		part := strings.TrimSpace(s[start:end])
+		switch {
+		case part == "" && sep == ' ':
+			return true
+		case part == "", n == len(out):
+			return false
+		}
+		if offs != nil {
+			offs[n] = start + strings.Index(s[start:end], part)
+		}
+		out[n], n = part, n+1
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth < 0 {
+				return 0, false
+			}
+		case depth == 0 && (c == sep || (sep == ' ' && isSpace(c))):
+			if !add(i) {
+				return 0, false
+			}
+			start = i + 1
+		}
+	}
+	if depth != 0 || !add(len(s)) {
+		return 0, false
+	}
+	return n, true
+}
+
+// channel is a channel of a CSS color function.
+type channel struct {
+	// ref is the value of 100%, or 0 for a <hue>.
+	ref float64
+	// lo and hi are the valid range, checked with [WithStrict].
+	lo, hi float64
+}
+
+// hue is a <hue> channel.
+var hue = channel{lo: math.Inf(-1), hi: math.Inf(1)}
+
+// parseChannels parses the last 3 channel arguments of the CSS function f,
+// returning their values. Percentages are converted using each channel's
+// ref, hues are converted to degrees, and the none keyword is returned as
+// NaN.
+func (o options) parseChannels(f cssFunc, chans [3]channel) ([3]float64, *ParseError) {
+	var v [3]float64
+	for i, c := range chans {
+		arg, off := f.args[f.n-3+i], f.offs[f.n-3+i]
+		x, unit, ok := parseValue(arg)
+		switch {
+		case !ok:
+			return v, errorf(off, "invalid channel %d %q", i+1, arg)
+		case math.IsNaN(x) && f.legacy:
+			return v, errorf(off, "none not allowed in legacy syntax")
+		case math.IsNaN(x):
+		case c.ref == 0:
+			if x, ok = parseHue(x, unit); !ok {
+				return v, errorf(off+len(arg)-len(unit), "invalid unit %q for channel %d", unit, i+1)
+			}
+		case unit == "%":
+			x = x / 100 * c.ref
+		case unit != "":
+			return v, errorf(off+len(arg)-len(unit), "invalid unit %q for channel %d", unit, i+1)
+		}
+		if o.strict && (x < c.lo || c.hi < x) {
+			return v, errorf(off, "channel %d out of range %s", i+1, formatRange(c.lo, c.hi, c.ref, unit))
+		}
+		v[i] = x
+	}
+	return v, nil
+}
+
+// parseAlpha parses the alpha of the CSS function f, returning the alpha
+// (0-1) clamped to the valid range, or 1 when f has no alpha. When
+// legacyAlpha is true, numbers are treated as 0-255.
+func (o options) parseAlpha(f cssFunc, legacyAlpha bool) (float64, *ParseError) {
+	if f.alpha == "" {
+		return 1, nil
+	}
+	v, unit, ok := parseValue(f.alpha)
+	hi := 1.0
+	switch {
+	case !ok, unit != "" && unit != "%", f.legacy && math.IsNaN(v):
+		return 0, errorf(f.alphaOff, "invalid alpha %q", f.alpha)
+	case math.IsNaN(v):
+		return 0, nil
+	case unit == "%":
+		v /= 100
+	case legacyAlpha:
+		hi = 0xff
+	}
+	if o.strict && (v < 0 || hi < v) {
+		return 0, errorf(f.alphaOff, "alpha out of range %s", formatRange(0, hi, 1, unit))
+	}
+	return clamp(v/hi, 0, 1), nil
+}
+
+// expect returns a parse error when the CSS function f does not have n
+// channel arguments, or uses the legacy syntax when legacy is false.
+func (f cssFunc) expect(n int, legacy bool) *ParseError {
+	off := len(f.name) + 1
+	switch {
+	case f.legacy && !legacy:
+		return errorf(off, "legacy (comma separated) syntax not supported")
+	case f.n != n:
+		return errorf(off, "expected %d channels, got %d", n, f.n)
+	}
+	return nil
+}
+
+// parseValue parses a CSS <number>, <percentage>, <angle>, or the none
+// keyword in s, returning the value and its unit. The none keyword is
+// returned as NaN.
+func parseValue(s string) (float64, string, bool) {
+	if strings.EqualFold(s, "none") {
+		return math.NaN(), "", true
+	}
+	i := numberLen(s)
+	if i == 0 {
+		return 0, "", false
+	}
+	v, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return v, s[i:], true
+}
+
+// numberLen returns the length of the CSS <number> at the start of s.
+func numberLen(s string) int {
+	i, n := 0, len(s)
+	if i < n && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digits := 0
+	for ; i < n && isDigit(s[i]); i++ {
+		digits++
+	}
+	if i+1 < n && s[i] == '.' && isDigit(s[i+1]) {
+		for i++; i < n && isDigit(s[i]); i++ {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return 0
+	}
+	if i < n && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < n && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < n && isDigit(s[j]) {
+			for i = j; i < n && isDigit(s[i]); i++ {
+			}
+		}
+	}
+	return i
+}
+
+// parseNumber parses a CSS <number> or <percentage> in s, where a
+// percentage of 100% is equal to ref. When legacy is true, the none keyword
+// is not allowed.
+func parseNumber(s string, ref float64, legacy bool) (float64, bool, bool) {
+	v, unit, ok := parseValue(s)
+	switch {
+	case !ok, legacy && math.IsNaN(v):
+		return 0, false, false
+	case unit == "%":
+		return v / 100 * ref, true, true
+	case unit == "":
+		return v, false, true
+	}
+	return 0, false, false
+}
+
+// isDigit returns true when c is a decimal digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// isSpace returns true when c is whitespace.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,266 @@
+package colors
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultVocabulary is the default vocabulary, used by [Describe].
+var DefaultVocabulary = EnglishVocabulary
+
+// EnglishVocabulary is the English vocabulary, describing colors such as
+// "dark muted blue", "pale yellowish green", "olive", and "light gray".
+var EnglishVocabulary = &Vocabulary{
+	Hues: []HueFamily{
+		{0, "pink", "pinkish", ""},
+		{29, "red", "reddish", ""},
+		{58, "orange", "orangish", "brown"},
+		{100, "yellow", "yellowish", "olive"},
+		{142, "green", "greenish", ""},
+		{195, "cyan", "cyan", ""},
+		{264, "blue", "bluish", ""},
+		{300, "violet", "violet", ""},
+		{330, "purple", "purplish", ""},
+	},
+	Lightness: []float64{0.3, 0.5, 0.7, 0.85, 1},
+	Dark:      0.5,
+	Chroma:    []float64{0.25, 0.5, 0.8, 1},
+	Tones: [][]string{
+		{"very dark grayish", "very dark muted", "very dark", "very deep"},
+		{"dark grayish", "dark muted", "dark", "deep"},
+		{"grayish", "muted", "", "vivid"},
+		{"light grayish", "light muted", "light", "vivid"},
+		{"pale", "pale", "very light", "vivid"},
+	},
+	Neutral: 0.02,
+	Neutrals: []Band{
+		{0.2, "black"},
+		{0.45, "dark gray"},
+		{0.7, "gray"},
+		{0.95, "light gray"},
+		{1, "white"},
+	},
+	Opacity: []Band{
+		{0.1, "nearly transparent"},
+		{0.9, "translucent"},
+		{1, ""},
+	},
+	Transparent: "transparent",
+}
+
+// Vocabulary is a vocabulary for describing colors by their hue family,
+// lightness, and chroma in the OKLCH color space. See [Vocabulary.Describe].
+//
+// The lightness, chroma, and opacity bands are defined by their upper
+// bounds, where values greater than the last bound are in the last band.
+type Vocabulary struct {
+	// Hues are the hue families, sorted by hue.
+	Hues []HueFamily
+	// Lightness are the upper bounds of the lightness bands, as OKLCH
+	// lightness (0-1). The lightness of chromatic colors is relative to the
+	// lightness of the most saturated color of their hue, such that a vivid
+	// yellow and a vivid blue are in the same band, and olive (#808000) is
+	// darker than its lightness alone.
+	Lightness []float64
+	// Dark is the upper bound of the lightness of colors described with the
+	// dark name of their hue family. See [HueFamily].
+	Dark float64
+	// Chroma are the upper bounds of the chroma bands, as the fraction of
+	// the maximum chroma in the sRGB gamut for the color's hue (0-1).
+	Chroma []float64
+	// Tones are the tone words for each lightness and chroma band, ex:
+	// Tones[1][1] is the tone of colors in the second lightness band and
+	// second chroma band, such as "dark muted".
+	Tones [][]string
+	// Neutral is the OKLCH chroma below which colors are achromatic.
+	Neutral float64
+	// Neutrals are the names of achromatic colors, by their upper bound of
+	// OKLCH lightness (0-1). Achromatic colors are described as an empty
+	// string when there are no neutral names.
+	Neutrals []Band
+	// Opacity are the opacity words, by their upper bound of alpha (0-1),
+	// ex: translucent.
+	Opacity []Band
+	// Transparent is the description of fully transparent colors. When
+	// empty, fully transparent colors are described by their opacity.
+	Transparent string
+	// Format formats a description from its parts. When nil, the non-empty
+	// parts are joined with spaces, in the order opacity, tone, modifier,
+	// and hue, ex: "pale yellowish green".
+	Format func(Description) string
+}
+
+// HueFamily is a hue family of a [Vocabulary].
+type HueFamily struct {
+	// Hue is the center of the hue family, in OKLCH degrees (0-360).
+	Hue float64
+	// Name is the name of the hue family, ex: green.
+	Name string
+	// Modifier is the modifier used for hues between the hue family and a
+	// neighboring hue family, ex: yellowish.
+	Modifier string
+	// Dark is the name of the hue family's dark colors, ex: olive for dark
+	// yellow, or empty. Dark names are used for colors in the hue family that
+	// are not between hue families, and that are darker than the
+	// vocabulary's Dark lightness, with the tones of the next lighter
+	// lightness band, ex: "olive" instead of "dark yellow".
+	Dark string
+}
+
+// Band is a named band of values with an upper bound.
+type Band struct {
+	Max  float64
+	Name string
+}
+
+// Description is the parts of a color's description, any of which may be
+// empty, ex: translucent, dark, yellowish, and green. The hue of achromatic
+// colors is their neutral name, ex: dark gray.
+type Description struct {
+	Opacity  string
+	Tone     string
+	Modifier string
+	Hue      string
+}
+
+// Describe returns a description of the color using the
+// [DefaultVocabulary], ex: "dark muted blue". See [Vocabulary.Describe].
+func Describe(c Color) string {
+	return DefaultVocabulary.Describe(c)
+}
+
+// Describe returns a description of the color, suitable for use as an
+// accessible label, ex: "dark muted blue" or "pale yellowish green".
+//
+// Achromatic colors are described by their lightness, ex: "dark gray".
+// Other colors are described by their tone, from their lightness and chroma
+// bands, and their hue family. Hues in the middle of 2 hue families are
+// described using the modifier of the further family, ex: "yellowish green".
+// Colors that are not opaque are described with their opacity, ex:
+// "translucent vivid red".
+func (v *Vocabulary) Describe(c Color) string {
+	if c.A == 0 && v.Transparent != "" {
+		return v.Transparent
+	}
+	d := Description{Opacity: bandName(v.Opacity, float64(c.A)/0xff)}
+	x := c.OKLCH()
+	if x.C < v.Neutral || len(v.Hues) == 0 {
+		d.Hue = bandName(v.Neutrals, x.L)
+		return v.format(d)
+	}
+	cuspL, cuspC := cusp(x.H)
+	chroma := x.C / cuspC
+	// lightness relative to the most saturated color of the hue
+	l := x.L + (cuspLightness-cuspL)*min(chroma, 1)
+	var f HueFamily
+	d.Modifier, f = v.hue(x.H)
+	i := band(v.Lightness, l)
+	if d.Hue = f.Name; d.Modifier == "" && f.Dark != "" && l <= v.Dark {
+		d.Hue, i = f.Dark, min(i+1, len(v.Lightness)-1)
+	}
+	if j := band(v.Chroma, chroma); i < len(v.Tones) && j < len(v.Tones[i]) {
+		d.Tone = v.Tones[i][j]
+	}
+	return v.format(d)
+}
+
+// format formats the description.
+func (v *Vocabulary) format(d Description) string {
+	if v.Format != nil {
+		return v.Format(d)
+	}
+	var words []string
+	for _, s := range []string{d.Opacity, d.Tone, d.Modifier, d.Hue} {
+		if s != "" {
+			words = append(words, s)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// hue returns the modifier and the hue family for the hue.
+func (v *Vocabulary) hue(h float64) (string, HueFamily) {
+	// find the families on either side of the hue
+	n := len(v.Hues)
+	i := n - 1
+	for j, f := range v.Hues {
+		if h < f.Hue {
+			break
+		}
+		i = j
+	}
+	a, b := v.Hues[i], v.Hues[(i+1)%n]
+	span := math.Mod(b.Hue-a.Hue+360, 360)
+	if span == 0 {
+		return "", a
+	}
+	switch t := math.Mod(h-a.Hue+360, 360) / span; {
+	case t < 1.0/3:
+		return "", a
+	case t < 0.5:
+		return b.Modifier, a
+	case t < 2.0/3:
+		return a.Modifier, b
+	}
+	return "", b
+}
+
+// band returns the index of the band containing the value, where values
+// greater than the last upper bound are in the last band.
+func band(bounds []float64, x float64) int {
+	for i, bound := range bounds {
+		if x <= bound {
+			return i
+		}
+	}
+	return max(len(bounds)-1, 0)
+}
+
+// bandName returns the name of the band containing the value, or an empty
+// string when there are no bands.
+func bandName(bands []Band, x float64) string {
+	for i, b := range bands {
+		if x <= b.Max || i == len(bands)-1 {
+			return b.Name
+		}
+	}
+	return ""
+}
+
+// cuspLightness is the lightness that the lightness of the most saturated
+// color of each hue is treated as when describing colors.
+const cuspLightness = 0.6
+
+// cusps are the OKLCH hues, lightnesses, and chromas of the most saturated
+// sRGB colors (the cusps of the sRGB gamut), sorted by hue.
+var cusps = sync.OnceValue(func() [][3]float64 {
+	v := make([][3]float64, 0, 360)
+	for h := range 360 {
+		r, g, b := hslToRGB(float64(h), 1, 0.5)
+		x := xyzToOKLab(srgbToXYZ65(r, g, b))
+		l, c, h := toPolar(x[0], x[1], x[2])
+		v = append(v, [3]float64{h, l, c})
+	}
+	sort.Slice(v, func(i, j int) bool {
+		return v[i][0] < v[j][0]
+	})
+	return v
+})
+
+// cusp returns the OKLCH lightness and chroma of the most saturated sRGB
+// color with the hue.
+func cusp(h float64) (float64, float64) {
+	v := cusps()
+	i := sort.Search(len(v), func(i int) bool {
+		return v[i][0] >= h
+	})
+	a, b := v[(i+len(v)-1)%len(v)], v[i%len(v)]
+	span := math.Mod(b[0]-a[0]+360, 360)
+	if span == 0 {
+		return a[1], a[2]
+	}
+	t := math.Mod(h-a[0]+360, 360) / span
+	return a[1] + t*(b[1]-a[1]), a[2] + t*(b[2]-a[2])
+}
@@ -0,0 +1,147 @@
+package colors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"red", "vivid red"},
+		{"darkred", "dark red"},
+		{"crimson", "vivid red"},
+		{"salmon", "light red"},
+		{"coral", "orangish red"},
+		{"orange", "vivid orange"},
+		{"peru", "orange"},
+		{"sienna", "dark reddish orange"},
+		{"saddlebrown", "brown"},
+		{"tan", "light muted yellowish orange"},
+		{"gold", "vivid yellow"},
+		{"yellow", "vivid yellow"},
+		{"darkgoldenrod", "dark orangish yellow"},
+		{"olive", "olive"},
+		{"khaki", "light yellow"},
+		{"beige", "pale yellow"},
+		{"olivedrab", "dark yellowish green"},
+		{"darkolivegreen", "dark muted yellowish green"},
+		{"lime", "vivid green"},
+		{"green", "dark green"},
+		{"teal", "dark cyan"},
+		{"cyan", "vivid cyan"},
+		{"darkslategray", "dark grayish cyan"},
+		{"skyblue", "light bluish cyan"},
+		{"steelblue", "blue"},
+		{"slategray", "grayish blue"},
+		{"blue", "vivid blue"},
+		{"navy", "dark blue"},
+		{"#34506b", "dark muted blue"},
+		{"indigo", "dark violet"},
+		{"lavender", "pale bluish violet"},
+		{"purple", "dark purple"},
+		{"magenta", "vivid purple"},
+		{"plum", "light muted purple"},
+		{"thistle", "light grayish purple"},
+		{"mediumvioletred", "vivid purplish pink"},
+		{"hotpink", "pink"},
+		{"deeppink", "vivid pink"},
+		{"pink", "pale pink"},
+		{"#c8e6a0", "light muted yellowish green"},
+		{"#e0f0c8", "pale yellowish green"},
+		{"black", "black"},
+		{"#333", "dark gray"},
+		{"gray", "gray"},
+		{"silver", "light gray"},
+		{"white", "white"},
+		{"#7f8088", "gray"},
+		{"transparent", "transparent"},
+		{"rgb(255 0 0 / 0.4%)", "nearly transparent vivid red"},
+		{"rgb(255 0 0 / 50%)", "translucent vivid red"},
+		{"rgb(128 128 128 / 50%)", "translucent gray"},
+		{"rgb(255 0 0 / 95%)", "vivid red"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, err := Parse(test.s)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if s := Describe(c); s != test.exp {
+				t.Errorf("expected %q, got: %q", test.exp, s)
+			}
+		})
+	}
+}
+
+func TestDescribeVocabulary(t *testing.T) {
+	v := &Vocabulary{
+		Hues: []HueFamily{
+			{29, "rojo", "rojizo", ""},
+			{100, "amarillo", "amarillento", ""},
+			{142, "verde", "verdoso", ""},
+			{255, "azul", "azulado", ""},
+		},
+		Lightness: []float64{0.5, 1},
+		Chroma:    []float64{0.5, 1},
+		Tones: [][]string{
+			{"oscuro apagado", "oscuro"},
+			{"claro apagado", "claro"},
+		},
+		Neutral: 0.02,
+		Neutrals: []Band{
+			{0.5, "negro"},
+			{1, "blanco"},
+		},
+		Opacity: []Band{
+			{0.9, "translúcido"},
+			{1, ""},
+		},
+		Format: func(d Description) string {
+			return strings.Join(strings.Fields(fmt.Sprintf("%s %s %s %s", d.Hue, d.Modifier, d.Tone, d.Opacity)), " ")
+		},
+	}
+	tests := []struct {
+		c   Color
+		exp string
+	}{
+		{New(0xff, 0, 0, 0xff), "rojo claro"},
+		{New(0, 0, 0x80, 0xff), "azul oscuro"},
+		{New(0xc8, 0xe6, 0xa0, 0xff), "verde amarillento claro apagado"},
+		{New(0x80, 0x80, 0x80, 0xff), "blanco"},
+		{New(0, 0, 0, 0xff), "negro"},
+		{Color{}, "negro translúcido"},
+		{New(0xff, 0, 0, 0x80), "rojo claro translúcido"},
+	}
+	for _, test := range tests {
+		if s := v.Describe(test.c); s != test.exp {
+			t.Errorf("%s: expected %q, got: %q", test.c.AsWeb(), test.exp, s)
+		}
+	}
+}
+
+func TestDescribeEmptyVocabulary(t *testing.T) {
+	v := &Vocabulary{
+		Hues: []HueFamily{
+			{29, "red", "reddish", ""},
+		},
+		Neutral: 0.02,
+	}
+	tests := []struct {
+		c   Color
+		exp string
+	}{
+		{New(0xff, 0, 0, 0xff), "red"},
+		{New(0xff, 0, 0, 0x80), "red"},
+		{New(0x80, 0x80, 0x80, 0xff), ""},
+		{Color{}, ""},
+	}
+	for _, test := range tests {
+		if s := v.Describe(test.c); s != test.exp {
+			t.Errorf("%s: expected %q, got: %q", test.c.AsWeb(), test.exp, s)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Error is a error.
+type Error string
+
+// Error satisfies the [error] interface.
+func (err Error) Error() string {
+	return string(err)
+}
+
+// Errors.
+const (
+	// ErrInvalidColor is invalid color error.
+	ErrInvalidColor Error = "invalid color"
+)
+
+// ParseError is a color parse error, returned by [Parse] and
+// [ParseFloatColor]. Wraps [ErrInvalidColor], and can be checked with
+// [errors.Is].
+type ParseError struct {
+	// Input is the input string.
+	Input string
+	// Offset is the byte offset in Input where the error was found.
+	Offset int
+	// Format is the format that was attempted, ex: web, rgb, hsl, x11, or
+	// name. Empty when the format could not be determined. See [Parser] for
+	// the format names.
+	Format string
+	// Reason is the reason the input is invalid, ex: channel 1 out of range
+	// 00-ff.
+	Reason string
+	// Suggestions are the names of similar colors, when the input is an
+	// unknown color name. See [Suggest].
+	Suggestions []NamedColor
+}
+
+// Error satisfies the [error] interface.
+//
+// Errors for unknown color names are formatted with any suggestions, ex:
+// invalid color "fuschia" (did you mean "fuchsia"?).
+func (err *ParseError) Error() string {
+	var s string
+	if err.Format == "name" && err.Offset <= len(err.Input) && strings.TrimSpace(err.Input[err.Offset:]) == strings.TrimSpace(err.Input) {
+		s = fmt.Sprintf("%s %q", ErrInvalidColor, err.Input)
+	} else {
+		s = fmt.Sprintf("%s %q at offset %d: ", ErrInvalidColor, err.Input, err.Offset)
+		if err.Format != "" {
+			s += err.Format + ": "
+		}
+		s += err.Reason
+	}
+	if len(err.Suggestions) == 0 {
+		return s
+	}
+	v := make([]string, len(err.Suggestions))
+	for i, name := range err.Suggestions {
+		v[i] = strconv.Quote(string(name))
+	}
+	switch len(v) {
+	case 1:
+		s += " (did you mean " + v[0]
+	case 2:
+		s += " (did you mean " + v[0] + " or " + v[1]
+	default:
+		s += " (did you mean " + strings.Join(v[:len(v)-1], ", ") + ", or " + v[len(v)-1]
+	}
+	return s + "?)"
+}
+
+// Unwrap returns [ErrInvalidColor].
+func (err *ParseError) Unwrap() error {
+	return ErrInvalidColor
+}
+
+// errNoMatch is returned by a format when the input is not in the format, as
+// opposed to being in the format, but invalid. Never returned to callers.
+var errNoMatch = &ParseError{Reason: "no match"}
+
+// errorf returns a parse error at the offset, with the reason formatted
+// using fmt.Sprintf.
+func errorf(offset int, format string, v ...any) *ParseError {
+	return &ParseError{Offset: offset, Reason: fmt.Sprintf(format, v...)}
+}
+
+// nested returns err with its offset adjusted by off, for errors in colors
+// nested within other colors, ex: the origin color of a relative color.
+func nested(err *ParseError, off int) *ParseError {
+	if err != nil && err != errNoMatch {
+		err.Offset += off
+	}
+	return err
+}
+
+// unknown returns the parse error for s when it is not in any of the
+// formats, ex: an unknown color name or function.
+func (o options) unknown(s string) *ParseError {
+	switch {
+	case s == "":
+		return &ParseError{Reason: "empty string"}
+	case funcName(s) != "":
+		return errorf(0, "unknown function %q", funcName(s))
+	}
+	return &ParseError{Format: "name", Reason: fmt.Sprintf("unknown color name %q", s), Suggestions: o.names().Suggest(s, 3)}
+}
+
+// formatRange formats the range lo, hi, as percentages of ref when unit is
+// %, ex: 0-255 or 0%-100%.
+func formatRange(lo, hi, ref float64, unit string) string {
+	if unit == "%" {
+		lo, hi = lo/ref*100, hi/ref*100
+	}
+	if math.IsInf(hi, 1) {
+		return formatFloat(lo, 6) + unit + " or greater"
+	}
+	return formatFloat(lo, 6) + unit + "-" + formatFloat(hi, 6) + unit
+}
@@ -0,0 +1,125 @@
+package colors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		s      string
+		offset int
+		format string
+		reason string
+	}{
+		{"", 0, "", "empty string"},
+		{"  ", 2, "", "empty string"},
+		{"mistyrouse", 0, "name", `unknown color name "mistyrouse"`},
+		{"  #ffe4g1", 7, "web", `invalid hex digit 'g'`},
+		{"#ffe4e", 0, "web", "invalid length 5, expected 3, 4, 6, or 8 digits"},
+		{"0xffe4e1f", 0, "web", "invalid length 7, expected 6 or 8 digits"},
+		{"ffe4e", 0, "web", "invalid length 5, expected 6 or 8 digits"},
+		{"rgb(0, 0)", 4, "rgb", "expected 3 channels, got 2"},
+		{"rgb(0, 0, a)", 10, "rgb", `invalid channel 3 "a"`},
+		{"rgb(0 0 0 0)", 4, "rgb", "expected 3 channels, got 4"},
+		{"rgb(0, 0 / 0)", 4, "rgb", "invalid argument separators"},
+		{"rgb(0, 0%, 0)", 4, "rgb", "cannot mix numbers and percentages in legacy syntax"},
+		{"rgb(0, 0, none)", 10, "rgb", "none not allowed in legacy syntax"},
+		{"rgb(0 0 10deg)", 10, "rgb", `invalid unit "deg" for channel 3`},
+		{"rgb(0 0 0 / 1deg)", 12, "rgb", `invalid alpha "1deg"`},
+		{"rgb(0 0 0", 9, "rgb", "missing closing parenthesis"},
+		{"rgb(0 0 0))", 10, "rgb", "unexpected closing parenthesis"},
+		{"rgb(0 0 0) x", 10, "rgb", "unexpected characters after closing parenthesis"},
+		{"hsl(0, 0, 0%)", 7, "hsl", "channel 2 must be a percentage"},
+		{"hsl(0foo 0% 0%)", 5, "hsl", `invalid unit "foo" for channel 1`},
+		{"hwb(0, 0%, 0%)", 4, "hwb", "legacy (comma separated) syntax not supported"},
+		{"lch(50 10 10%)", 12, "lch", `invalid unit "%" for channel 3`},
+		{"hex(100, 0, 0)", 4, "hex", "channel 1 out of range 00-ff"},
+		{"hex(0, 0, 0, zz)", 13, "hex", `invalid channel 4 "zz"`},
+		{"hex(0 0 0)", 4, "hex", "expected comma separated channels"},
+		{"color(foo 1 0 0)", 6, "color", `unknown color space "foo"`},
+		{"xterm:300", 6, "xterm", "index 300 out of range 0-255"},
+		{"xterm:3x", 6, "xterm", `invalid index "3x"`},
+		{"300", 0, "name", `unknown color name "300"`},
+		{"color( 256 )", 7, "xterm", "index 256 out of range 0-255"},
+		{"ansi:brightorange", 5, "xterm", `unknown ANSI color "brightorange"`},
+		{"ansi:9red", 5, "xterm", `unknown ANSI color "9red"`},
+		{"color(srgb 1 0)", 6, "color", "expected a color space and 3 channels, got 3 arguments"},
+		{"foo(1 2 3)", 0, "", `unknown function "foo"`},
+		{"rgb:0/0", 4, "x11", "expected 3 channels, got 2"},
+		{"rgb:0/fffff/0", 6, "x11", "channel 2 must have 1-4 hex digits"},
+		{"rgb:0/0/0g", 9, "x11", "invalid hex digit 'g'"},
+		{"rgbi:0/2/0", 7, "x11", "channel 2 out of range 0-1"},
+		{"TekHVC:0/200/0", 9, "x11", "channel 2 out of range 0-100"},
+		{"CIEXYZ:0/x/0", 9, "x11", `invalid channel 2 "x"`},
+		{"foo:0/0/0", 0, "name", `unknown color name "foo:0/0/0"`},
+		{"material:red-500", 0, "name", `unknown color name "material:red-500"`},
+		{"var(--brand)", 4, "var", "undefined custom property --brand"},
+		{"var(brand)", 4, "var", `invalid custom property name "brand"`},
+		{"var(--brand, #ffe4g1)", 18, "web", `invalid hex digit 'g'`},
+		{"rgb(from #ffe4g1 r g b)", 14, "web", `invalid hex digit 'g'`},
+		{"rgb(from red r g)", 4, "rgb", "invalid relative color"},
+		{"color-mix(in srgb, red)", 10, "color-mix", "expected an interpolation method and 2 colors"},
+		{"color-mix(in foo, red, blue)", 13, "color-mix", `unknown color space "foo"`},
+		{"color-mix(on srgb, red, blue)", 10, "color-mix", `invalid interpolation method "on srgb"`},
+		{"color-mix(in srgb, red, 50% rgb(0 0 0 / x))", 40, "rgb", `invalid alpha "x"`},
+		{"color-mix(in srgb, red 150%, blue)", 19, "color-mix", "invalid percentage for color 1, expected 0-100%"},
+		{"color-mix(in srgb, red 0%, blue 0%)", 10, "color-mix", "percentages must not sum to 0%"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			_, err := Parse(test.s)
+			if !errors.Is(err, ErrInvalidColor) {
+				t.Fatalf("expected ErrInvalidColor, got: %v", err)
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("expected *ParseError, got: %T", err)
+			}
+			if pe.Input != test.s {
+				t.Errorf("expected input %q, got: %q", test.s, pe.Input)
+			}
+			if pe.Offset != test.offset {
+				t.Errorf("expected offset %d, got: %d", test.offset, pe.Offset)
+			}
+			if pe.Format != test.format {
+				t.Errorf("expected format %q, got: %q", test.format, pe.Format)
+			}
+			if pe.Reason != test.reason {
+				t.Errorf("expected reason %q, got: %q", test.reason, pe.Reason)
+			}
+			t.Logf("%v", err)
+		})
+	}
+}
+
+func TestParseErrorVar(t *testing.T) {
+	resolve := func(name string) (string, bool) {
+		return "#ffe4g1", name == "--brand"
+	}
+	_, err := Parse("var(--brand)", WithVarResolver(resolve))
+	var pe *ParseError
+	switch {
+	case !errors.As(err, &pe):
+		t.Fatalf("expected *ParseError, got: %v", err)
+	case pe.Reason != `custom property --brand has invalid value "#ffe4g1"`:
+		t.Errorf("unexpected reason: %q", pe.Reason)
+	}
+	const exp = `invalid color "var(--brand)" at offset 4: var: custom property --brand has invalid value "#ffe4g1"`
+	if s := err.Error(); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	var c Color
+	err := c.UnmarshalText([]byte("#ffe4g1"))
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Offset != 5 {
+		t.Errorf("expected *ParseError with offset 5, got: %v", err)
+	}
+	err = c.Pflag().Set("rgb(0, 0)")
+	if !errors.As(err, &pe) || pe.Format != "rgb" || !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("expected rgb *ParseError, got: %v", err)
+	}
+}
@@ -17,7 +17,7 @@ func Example() {
 		"rgb(0,255,0)",
 		"hex(f,e,a)",
 		"#fff",
-		"rgba(26,33,80,22)",
+		"rgba(26,33,80,0.086)",
 		"#cdcdcd80",
 		"#cdcdcdff",
 		"hex(0,0,0,0)",
@@ -0,0 +1,217 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// FloatColor is a color with float64 channels in a color space. Unlike
+// [Color], a FloatColor retains values that are outside of the sRGB gamut.
+//
+// Channels are in the ranges used by CSS for the color space (see [Space]),
+// and Alpha is 0-1. Missing channels (the CSS none keyword) are NaN.
+type FloatColor struct {
+	Space    Space
+	Channels [3]float64
+	Alpha    float64
+}
+
+// NewFloat creates a new float color in the color space.
+func NewFloat(space Space, c0, c1, c2, alpha float64) FloatColor {
+	return FloatColor{space, [3]float64{c0, c1, c2}, alpha}
+}
+
+// ParseFloatColor parses a color, retaining the precision and any out of
+// gamut values of the color(), color-mix(), and relative color functions and
+// the CIE and OK color spaces. All other representations supported by
+// [Parse] are returned in the sRGB color space. See [Parse] for the options.
+func ParseFloatColor(s string, opts ...Option) (FloatColor, error) {
+	return DefaultParser.With(opts...).ParseFloat(s)
+}
+
+// FromColorFunc converts a CSS color() function string to a color, ex:
+// color(display-p3 1 0 0) or color(rec2020 0.5 25% 0 / 0.5).
+//
+// Supports the srgb, srgb-linear, display-p3, a98-rgb, prophoto-rgb, rec2020,
+// xyz, xyz-d50, and xyz-d65 color spaces. Channels may be numbers or
+// percentages, where 100% is 1. Colors outside of the sRGB gamut are gamut
+// mapped. See [FloatColor.Color].
+func FromColorFunc(s string) (Color, bool) {
+	c, err := options{}.parseColorFunc(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// Float returns the color as a [FloatColor] in the sRGB color space.
+func (c Color) Float() FloatColor {
+	return FloatColor{SpaceSRGB, [3]float64{float64(c.R) / 0xff, float64(c.G) / 0xff, float64(c.B) / 0xff}, float64(c.A) / 0xff}
+}
+
+// Convert converts the color to the color space. Missing channels are treated
+// as 0.
+func (c FloatColor) Convert(space Space) FloatColor {
+	if c.Space == space {
+		return c
+	}
+	return FloatColor{space, fromXYZ65(space, toXYZ65(c.Space, apply(c.Channels, noneToZero))), c.Alpha}
+}
+
+// InGamut returns true when the color is within the gamut of its color space.
+// Colors in unbounded color spaces (CIE XYZ, Lab, and OKLab) are always in
+// gamut.
+func (c FloatColor) InGamut() bool {
+	switch {
+	case !c.Space.Bounded():
+		return true
+	case c.Space == SpaceHSL || c.Space == SpaceHWB:
+		c = c.Convert(SpaceSRGB)
+	}
+	v := apply(c.Channels, noneToZero)
+	return inGamut(v[0], v[1], v[2])
+}
+
+// Clip returns the color with its channels clipped to the gamut of its color
+// space.
+func (c FloatColor) Clip() FloatColor {
+	switch {
+	case !c.Space.Bounded():
+		return c
+	case c.Space == SpaceHSL || c.Space == SpaceHWB:
+		return c.Convert(SpaceSRGB).Clip().Convert(c.Space)
+	}
+	for i, v := range c.Channels {
+		c.Channels[i] = clamp(noneToZero(v), 0, 1)
+	}
+	return c
+}
+
+// GamutMap converts the color to the color space, reducing the chroma of
+// colors outside of the color space's gamut using the CSS Color Level 4
+// gamut mapping algorithm.
+//
+// See: https://www.w3.org/TR/css-color-4/#binsearch
+func (c FloatColor) GamutMap(space Space) FloatColor {
+	switch {
+	case !space.Bounded():
+		return c.Convert(space)
+	case space == SpaceHSL || space == SpaceHWB:
+		return c.GamutMap(SpaceSRGB).Convert(space)
+	}
+	const jnd, eps = 0.02, 0.0001
+	current := c.Convert(SpaceOKLCH)
+	switch l := noneToZero(current.Channels[0]); {
+	case l >= 1:
+		return NewFloat(SpaceOKLab, 1, 0, 0, c.Alpha).Convert(space)
+	case l <= 0:
+		return NewFloat(SpaceOKLab, 0, 0, 0, c.Alpha).Convert(space)
+	}
+	dest := c.Convert(space)
+	if dest.InGamut() {
+		return dest
+	}
+	clipped := dest.Clip()
+	if deltaEOK(clipped, current) < jnd {
+		return clipped
+	}
+	lo, hi, loInGamut := 0.0, current.Channels[1], true
+	for hi-lo > eps {
+		chroma := (lo + hi) / 2
+		current.Channels[1] = chroma
+		if dest = current.Convert(space); loInGamut && dest.InGamut() {
+			lo = chroma
+			continue
+		}
+		clipped = dest.Clip()
+		switch e := deltaEOK(clipped, current); {
+		case e >= jnd:
+			hi = chroma
+		case jnd-e < eps:
+			return clipped
+		default:
+			lo, loInGamut = chroma, false
+		}
+	}
+	return clipped
+}
+
+// Color converts the color to a [Color], gamut mapping colors that are
+// outside of the sRGB gamut. See [FloatColor.GamutMap].
+func (c FloatColor) Color() Color {
+	v := c.GamutMap(SpaceSRGB)
+	return New(toUint8(v.Channels[0]), toUint8(v.Channels[1]), toUint8(v.Channels[2]), toUint8(c.Alpha))
+}
+
+// RGBA satisfies the [color.Color] interface.
+func (c FloatColor) RGBA() (r, g, b, a uint32) {
+	return c.Color().RGBA()
+}
+
+// String satisfies the [fmt.Stringer] interface, formatting the color as a
+// CSS color, ex: color(display-p3 1 0 0) or oklch(0.628 0.2577 29.23 / 0.5).
+func (c FloatColor) String() string {
+	var v [3]string
+	for i, f := range c.Channels {
+		v[i] = "none"
+		if !math.IsNaN(f) {
+			v[i] = formatFloat(f, 6)
+		}
+	}
+	var s string
+	switch c.Space {
+	case SpaceHSL, SpaceHWB:
+		s = fmt.Sprintf("%s(%s %s%% %s%%", c.Space, v[0], v[1], v[2])
+	case SpaceLab, SpaceLCh, SpaceOKLab, SpaceOKLCH:
+		s = fmt.Sprintf("%s(%s %s %s", c.Space, v[0], v[1], v[2])
+	default:
+		s = fmt.Sprintf("color(%s %s %s %s", c.Space, v[0], v[1], v[2])
+	}
+	if c.Alpha != 1 {
+		s += " / " + formatFloat(c.Alpha, 6)
+	}
+	return s + ")"
+}
+
+// parseColorFunc parses a CSS color() function string. A single palette
+// index (ex: color(208)) is not a color() function. See [FromXterm].
+func (o options) parseColorFunc(s string) (FloatColor, *ParseError) {
+	f, err := parseNamedFunc(s, "color")
+	switch {
+	case err != nil:
+		return FloatColor{}, err
+	case f.n == 1 && f.alpha == "" && isIndex(f.args[0]):
+		return FloatColor{}, errNoMatch
+	}
+	var space Space
+	if f.n != 0 {
+		var ok bool
+		if space, ok = ParseSpace(f.args[0]); !ok || space.Polar() != -1 || space == SpaceLab || space == SpaceOKLab {
+			return FloatColor{}, errorf(f.offs[0], "unknown color space %q", f.args[0])
+		}
+	}
+	switch {
+	case f.legacy:
+		return FloatColor{}, f.expect(4, false)
+	case f.n != 4:
+		return FloatColor{}, errorf(len("color("), "expected a color space and 3 channels, got %d arguments", f.n)
+	}
+	ch := channel{1, math.Inf(-1), math.Inf(1)}
+	if space.Bounded() {
+		ch = channel{1, 0, 1}
+	}
+	c := FloatColor{Space: space}
+	if c.Channels, err = o.parseChannels(f, [3]channel{ch, ch, ch}); err != nil {
+		return FloatColor{}, err
+	}
+	if c.Alpha, err = o.parseAlpha(f, false); err != nil {
+		return FloatColor{}, err
+	}
+	return c, nil
+}
+
+// deltaEOK returns the color difference of a and b in the OKLab color space.
+func deltaEOK(a, b FloatColor) float64 {
+	x, y := a.Convert(SpaceOKLab).Channels, b.Convert(SpaceOKLab).Channels
+	return math.Sqrt((x[0]-y[0])*(x[0]-y[0]) + (x[1]-y[1])*(x[1]-y[1]) + (x[2]-y[2])*(x[2]-y[2]))
+}
@@ -0,0 +1,118 @@
+package colors
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestFloatConvert(t *testing.T) {
+	red := Red.Color().Float()
+	tests := []struct {
+		space Space
+		exp   [3]float64
+	}{
+		{SpaceSRGB, [3]float64{1, 0, 0}},
+		{SpaceSRGBLinear, [3]float64{1, 0, 0}},
+		{SpaceDisplayP3, [3]float64{0.91749, 0.20029, 0.13856}},
+		{SpaceA98RGB, [3]float64{0.85859, 0, 0}},
+		{SpaceProPhotoRGB, [3]float64{0.70225, 0.27572, 0.10355}},
+		{SpaceRec2020, [3]float64{0.79198, 0.23098, 0.07376}},
+		{SpaceXYZD50, [3]float64{0.43607, 0.22249, 0.01392}},
+		{SpaceXYZD65, [3]float64{0.41239, 0.21264, 0.01933}},
+		{SpaceHSL, [3]float64{0, 100, 50}},
+		{SpaceHWB, [3]float64{0, 0, 0}},
+		{SpaceLab, [3]float64{54.29054, 80.80492, 69.89098}},
+		{SpaceLCh, [3]float64{54.29054, 106.83718, 40.85766}},
+		{SpaceOKLab, [3]float64{0.62796, 0.22486, 0.12585}},
+		{SpaceOKLCH, [3]float64{0.62796, 0.25768, 29.23389}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.space), func(t *testing.T) {
+			v := red.Convert(test.space)
+			t.Logf("%s", v)
+			if i := test.space.Polar(); i != -1 && v.Channels[i] > 359.9999 {
+				v.Channels[i] -= 360
+			}
+			if !near(0.0001, v.Channels[0], test.exp[0], v.Channels[1], test.exp[1], v.Channels[2], test.exp[2]) {
+				t.Errorf("expected %v, got: %v", test.exp, v.Channels)
+			}
+			if !v.InGamut() {
+				t.Errorf("expected %s to be in gamut", v)
+			}
+			if c := v.Color(); !c.Is(Red) {
+				t.Errorf("expected red, got: %v", c)
+			}
+			white := White.Color().Float().Convert(test.space)
+			if c := white.Convert(SpaceSRGB); !near(0.0001, c.Channels[0], 1, c.Channels[1], 1, c.Channels[2], 1) {
+				t.Errorf("expected white, got: %s", c)
+			}
+		})
+	}
+}
+
+func TestGamutMap(t *testing.T) {
+	tests := []struct {
+		c   FloatColor
+		exp Color
+	}{
+		{NewFloat(SpaceSRGB, 0.5, 0.5, 0.5, 1), New(128, 128, 128, 255)},
+		{NewFloat(SpaceDisplayP3, 1, 0, 0, 1), New(255, 11, 12, 255)},
+		{NewFloat(SpaceDisplayP3, 0, 1, 0, 1), New(0, 251, 41, 255)},
+		{NewFloat(SpaceRec2020, 0, 0, 1, 0.5), New(0, 81, 147, 128)},
+		{NewFloat(SpaceOKLCH, 1.2, 0.4, 120, 1), New(255, 255, 255, 255)},
+		{NewFloat(SpaceOKLCH, -0.1, 0.4, 120, 1), New(0, 0, 0, 255)},
+		{NewFloat(SpaceOKLCH, 0.7, 0.4, 150, 1), New(0, 194, 72, 255)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			v := test.c.GamutMap(SpaceSRGB)
+			t.Logf("%s -> %s", test.c, v)
+			if !v.InGamut() {
+				t.Errorf("expected %s to be in gamut", v)
+			}
+			if c := test.c.Color(); !near(2, float64(c.R), float64(test.exp.R), float64(c.G), float64(test.exp.G), float64(c.B), float64(test.exp.B), float64(c.A), float64(test.exp.A)) {
+				t.Errorf("expected %v, got: %v", test.exp, c)
+			}
+			// gamut mapping preserves hue and lightness more than clipping
+			if test.c.InGamut() {
+				return
+			}
+			a, b := test.c.Convert(SpaceOKLCH), v.Convert(SpaceOKLCH)
+			c := test.c.Convert(SpaceSRGB).Clip().Convert(SpaceOKLCH)
+			if deltaEOK(a, b) > deltaEOK(a, c)+0.02 {
+				t.Errorf("expected gamut mapped %s to be closer to %s than clipped %s", b, a, c)
+			}
+		})
+	}
+}
+
+func TestParseFloatColor(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"color(display-p3 1 0 0)", "color(display-p3 1 0 0)"},
+		{"color(display-p3 1.5 -0.5 none / 50%)", "color(display-p3 1.5 -0.5 none / 0.5)"},
+		{"COLOR(XYZ 0.5 0.5 0.5)", "color(xyz-d65 0.5 0.5 0.5)"},
+		{"lab(50 200 -200)", "lab(50 200 -200)"},
+		{"lch(50% 100% none)", "lch(50 150 none)"},
+		{"oklab(50% 0.5 0)", "oklab(0.5 0.5 0)"},
+		{"oklch(0.5 0.5 1turn)", "oklch(0.5 0.5 360)"},
+		{"red", "color(srgb 1 0 0)"},
+		{"#80808080", "color(srgb 0.501961 0.501961 0.501961 / 0.501961)"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			c, err := ParseFloatColor(test.s)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if s := c.String(); s != test.exp {
+				t.Errorf("expected %q, got: %q", test.exp, s)
+			}
+		})
+	}
+	if _, err := ParseFloatColor("color(display-p3 1 0)"); err == nil {
+		t.Errorf("expected error")
+	}
+}
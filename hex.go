@@ -0,0 +1,161 @@
+package colors
+
+// ByteOrder is the order of a color's channels when packed into an integer,
+// or written as a hex literal, from the most significant byte to the least.
+type ByteOrder int
+
+// Byte orders.
+//
+// OrderBGR has no alpha, and is the order of the Windows COLORREF type
+// (0x00bbggrr), where the most significant byte is unused.
+const (
+	// OrderRGBA is the CSS order, ex: #rrggbbaa.
+	OrderRGBA ByteOrder = iota
+	// OrderARGB is the order used by Android, Qt, and .NET, ex: 0xaarrggbb.
+	OrderARGB
+	// OrderBGR is the order used by the Windows COLORREF type, ex:
+	// 0x00bbggrr.
+	OrderBGR
+	// OrderABGR is the order used by little-endian RGBA pixel data, ex:
+	// 0xaabbggrr.
+	OrderABGR
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (order ByteOrder) String() string {
+	switch order {
+	case OrderARGB:
+		return "ARGB"
+	case OrderBGR:
+		return "BGR"
+	case OrderABGR:
+		return "ABGR"
+	}
+	return "RGBA"
+}
+
+// FromUint32 creates a color from v, with the channels packed in the byte
+// order. Colors in the [OrderBGR] byte order are opaque.
+func FromUint32(v uint32, order ByteOrder) Color {
+	b0, b1, b2, b3 := uint8(v>>24), uint8(v>>16), uint8(v>>8), uint8(v)
+	switch order {
+	case OrderARGB:
+		return New(b1, b2, b3, b0)
+	case OrderBGR:
+		return New(b3, b2, b1, 0xff)
+	case OrderABGR:
+		return New(b3, b2, b1, b0)
+	}
+	return New(b0, b1, b2, b3)
+}
+
+// Uint32 returns the color's channels packed into a uint32 in the byte order.
+// The alpha is dropped with the [OrderBGR] byte order.
+func (c Color) Uint32(order ByteOrder) uint32 {
+	r, g, b, a := uint32(c.R), uint32(c.G), uint32(c.B), uint32(c.A)
+	switch order {
+	case OrderARGB:
+		return a<<24 | r<<16 | g<<8 | b
+	case OrderBGR:
+		return b<<16 | g<<8 | r
+	case OrderABGR:
+		return a<<24 | b<<16 | g<<8 | r
+	}
+	return r<<24 | g<<16 | b<<8 | a
+}
+
+// parseHexLiteral parses a hex literal, using the byte order when set, or
+// the default byte order of the literal's form otherwise.
+//
+// Literals prefixed with # may have 3, 4, 6, or 8 digits, and default to the
+// CSS byte order ([OrderRGBA]). Literals prefixed with 0x may have 6 or 8
+// digits, and default to [OrderARGB]. Bare literals may have 6 or 8 digits,
+// and default to [OrderRGBA]. The 3 and 4 digit forms are expanded by
+// repeating each digit, ex: #abc is #aabbcc.
+//
+// The X11 #rrrgggbbb and #rrrrggggbbbb forms, and bare words and numbers that
+// are not 6 or 8 digits (ex: bead or 300), are rejected with errNoMatch. See
+// [FromX11] and [FromXterm].
+func parseHexLiteral(s string, order *ByteOrder) (Color, *ParseError) {
+	def, start, lengths := OrderRGBA, 0, "6 or 8"
+	switch {
+	case len(s) != 0 && s[0] == '#':
+		if n := len(s) - 1; (n == 9 || n == 12) && isHex(s[1:]) {
+			return Color{}, errNoMatch
+		}
+		start, lengths = 1, "3, 4, 6, or 8"
+	case len(s) > 1 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X'):
+		def, start = OrderARGB, 2
+	case !isHex(s), len(s) != 6 && len(s) != 8 && !isMixed(s):
+		return Color{}, errNoMatch
+	}
+	if order != nil {
+		def = *order
+	}
+	var v uint32
+	for i := start; i < len(s); i++ {
+		d, ok := hexDigit(s[i])
+		if !ok {
+			return Color{}, errorf(i, "invalid hex digit %q", s[i])
+		}
+		v = v<<4 | uint32(d)
+	}
+	switch n := len(s) - start; {
+	case start == 1 && (n == 3 || n == 4):
+		// expand each digit, ex: 0xabc to 0xaabbcc
+		var x uint32
+		for i := n - 1; i >= 0; i-- {
+			d := v >> (4 * uint(n-1-i)) & 0xf
+			x |= (d<<4 | d) << (8 * uint(n-1-i))
+		}
+		v = x
+	case n != 6 && n != 8:
+		return Color{}, errorf(0, "invalid length %d, expected %s digits", n, lengths)
+	}
+	if n := len(s) - start; n == 3 || n == 6 {
+		// no alpha
+		switch def {
+		case OrderRGBA:
+			v = v<<8 | 0xff
+		case OrderARGB, OrderABGR:
+			v |= 0xff << 24
+		}
+	}
+	return FromUint32(v, def), nil
+}
+
+// isHex returns true when s is made of hex digits.
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if _, ok := hexDigit(s[i]); !ok {
+			return false
+		}
+	}
+	return len(s) != 0
+}
+
+// isMixed returns true when s contains both decimal digits and letters.
+func isMixed(s string) bool {
+	var digit, letter bool
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			digit = true
+		} else {
+			letter = true
+		}
+	}
+	return digit && letter
+}
+
+// hexDigit returns the value of the hex digit c.
+func hexDigit(c byte) (uint8, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
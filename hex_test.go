@@ -0,0 +1,106 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestFromWeb(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp color.NRGBA
+	}{
+		{"#abc", color.NRGBA{0xaa, 0xbb, 0xcc, 0xff}},
+		{"#abcd", color.NRGBA{0xaa, 0xbb, 0xcc, 0xdd}},
+		{"#AbCdEf", color.NRGBA{0xab, 0xcd, 0xef, 0xff}},
+		{"#abcdef80", color.NRGBA{0xab, 0xcd, 0xef, 0x80}},
+		{"0xabcdef", color.NRGBA{0xab, 0xcd, 0xef, 0xff}},
+		{"0x80abcdef", color.NRGBA{0xab, 0xcd, 0xef, 0x80}},
+		{"0X80ABCDEF", color.NRGBA{0xab, 0xcd, 0xef, 0x80}},
+		{"abcdef", color.NRGBA{0xab, 0xcd, 0xef, 0xff}},
+		{"abcdef80", color.NRGBA{0xab, 0xcd, 0xef, 0x80}},
+		{"ffffff", color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+		{"deadbeef", color.NRGBA{0xde, 0xad, 0xbe, 0xef}},
+		{"#", color.NRGBA{}},
+		{"#ab", color.NRGBA{}},
+		{"#abcde", color.NRGBA{}},
+		{"0xabc", color.NRGBA{}},
+		{"abc", color.NRGBA{}},
+		{"#abcdeg", color.NRGBA{}},
+		{"0x-abcde", color.NRGBA{}},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, ok := FromWeb(test.s)
+			switch {
+			case test.exp == (color.NRGBA{}) && ok:
+				t.Fatalf("expected error, got: %v", c)
+			case test.exp == (color.NRGBA{}):
+			case !ok:
+				t.Fatalf("expected ok")
+			case !c.Is(test.exp):
+				t.Errorf("expected %v, got: %#v", test.exp, c)
+			}
+		})
+	}
+}
+
+func TestWithByteOrder(t *testing.T) {
+	tests := []struct {
+		s     string
+		order ByteOrder
+		exp   color.NRGBA
+	}{
+		{"#aabbcc", OrderRGBA, color.NRGBA{0xaa, 0xbb, 0xcc, 0xff}},
+		{"#aabbccdd", OrderRGBA, color.NRGBA{0xaa, 0xbb, 0xcc, 0xdd}},
+		{"0xaabbccdd", OrderRGBA, color.NRGBA{0xaa, 0xbb, 0xcc, 0xdd}},
+		{"#aabbcc", OrderARGB, color.NRGBA{0xaa, 0xbb, 0xcc, 0xff}},
+		{"#80ff0000", OrderARGB, color.NRGBA{0xff, 0, 0, 0x80}},
+		{"#8f00", OrderARGB, color.NRGBA{0xff, 0, 0, 0x88}},
+		{"80ff0000", OrderARGB, color.NRGBA{0xff, 0, 0, 0x80}},
+		{"0x00e1e4ff", OrderBGR, color.NRGBA{0xff, 0xe4, 0xe1, 0xff}},
+		{"0xffe1e4ff", OrderBGR, color.NRGBA{0xff, 0xe4, 0xe1, 0xff}},
+		{"0xe1e4ff", OrderBGR, color.NRGBA{0xff, 0xe4, 0xe1, 0xff}},
+		{"#00f", OrderBGR, color.NRGBA{0xff, 0, 0, 0xff}},
+		{"0x80e1e4ff", OrderABGR, color.NRGBA{0xff, 0xe4, 0xe1, 0x80}},
+		{"0xe1e4ff", OrderABGR, color.NRGBA{0xff, 0xe4, 0xe1, 0xff}},
+	}
+	for _, test := range tests {
+		t.Run(test.order.String()+" "+test.s, func(t *testing.T) {
+			c, err := Parse(test.s, WithByteOrder(test.order))
+			switch {
+			case err != nil:
+				t.Fatalf("expected no error, got: %v", err)
+			case !c.Is(test.exp):
+				t.Errorf("expected %v, got: %#v", test.exp, c)
+			}
+		})
+	}
+}
+
+func TestUint32(t *testing.T) {
+	c := New(0x11, 0x22, 0x33, 0x44)
+	tests := []struct {
+		order ByteOrder
+		exp   uint32
+		c     Color
+	}{
+		{OrderRGBA, 0x11223344, c},
+		{OrderARGB, 0x44112233, c},
+		{OrderBGR, 0x00332211, New(0x11, 0x22, 0x33, 0xff)},
+		{OrderABGR, 0x44332211, c},
+	}
+	for _, test := range tests {
+		t.Run(test.order.String(), func(t *testing.T) {
+			if v := c.Uint32(test.order); v != test.exp {
+				t.Errorf("expected %08x, got: %08x", test.exp, v)
+			}
+			if v := FromUint32(test.exp, test.order); v != test.c {
+				t.Errorf("expected %#v, got: %#v", test.c, v)
+			}
+		})
+	}
+	if c := FromUint32(0xffe4e1ff, OrderRGBA); c.NamedColor != Mistyrose {
+		t.Errorf("expected %s, got: %#v", Mistyrose, c)
+	}
+}
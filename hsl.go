@@ -0,0 +1,129 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FromHSL converts a hsl string to a color, ex: hsl(210, 40%, 50%) or
+// hsl(210deg 40% 50% / 50%).
+//
+// The hue may be specified in degrees (the default when no unit is given),
+// or with one of the deg, rad, grad, or turn units. Saturation and lightness
+// are percentages, and are clamped to 0-100%. An optional alpha may be
+// specified as a number between 0 and 1, or as a percentage.
+func FromHSL(s string) (Color, bool) {
+	c, err := options{}.parseHSL(s, "hsl")
+	return c, err == nil
+}
+
+// FromHSLA converts a hsla string to a color, ex: hsla(210, 40%, 50%, 0.5).
+//
+// See [FromHSL] for the supported units.
+func FromHSLA(s string) (Color, bool) {
+	c, err := options{}.parseHSL(s, "hsla")
+	return c, err == nil
+}
+
+// AsHSL returns the color formatted as a hsl string, ex: hsl(210,40%,50%).
+func (c Color) AsHSL() string {
+	h, s, l := rgbToHSL(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff)
+	return fmt.Sprintf("hsl(%s,%s%%,%s%%)", formatFloat(h, 2), formatFloat(s*100, 2), formatFloat(l*100, 2))
+}
+
+// AsHSLA returns the color formatted as a hsla string, ex:
+// hsla(210,40%,50%,0.5).
+func (c Color) AsHSLA() string {
+	h, s, l := rgbToHSL(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff)
+	return fmt.Sprintf("hsla(%s,%s%%,%s%%,%s)", formatFloat(h, 2), formatFloat(s*100, 2), formatFloat(l*100, 2), formatFloat(float64(c.A)/0xff, 3))
+}
+
+// hslChannels are the channels of the hsl and hwb functions, where the
+// saturation and lightness, or whiteness and blackness, are 0-100.
+var hslChannels = [3]channel{hue, {100, 0, 100}, {100, 0, 100}}
+
+// parseHSL parses a hsl or hsla string for the named function.
+func (o options) parseHSL(s, name string) (Color, *ParseError) {
+	f, err := parseNamedFunc(s, name)
+	if err != nil {
+		return Color{}, err
+	}
+	if err := f.expect(3, true); err != nil {
+		return Color{}, err
+	}
+	v, err := o.parseChannels(f, hslChannels)
+	if err != nil {
+		return Color{}, err
+	}
+	// legacy syntax requires percentages
+	for i := 1; i < 3 && f.legacy; i++ {
+		if !isPercent(f.args[i]) {
+			return Color{}, errorf(f.offs[i], "channel %d must be a percentage", i+1)
+		}
+	}
+	a, err := o.parseAlpha(f, false)
+	if err != nil {
+		return Color{}, err
+	}
+	r, g, b := hslToRGB(noneToZero(v[0]), clamp(noneToZero(v[1])/100, 0, 1), clamp(noneToZero(v[2])/100, 0, 1))
+	return New(toUint8(r), toUint8(g), toUint8(b), toUint8(a)), nil
+}
+
+// parseHue converts a hue with the specified unit to degrees.
+func parseHue(h float64, unit string) (float64, bool) {
+	switch {
+	case unit == "", strings.EqualFold(unit, "deg"):
+	case strings.EqualFold(unit, "rad"):
+		h = h * 180 / math.Pi
+	case strings.EqualFold(unit, "grad"):
+		h = h * 360 / 400
+	case strings.EqualFold(unit, "turn"):
+		h *= 360
+	default:
+		return 0, false
+	}
+	return h, true
+}
+
+// hslToRGB converts hue (degrees), saturation and lightness (0-1) to red,
+// green and blue (0-1).
+//
+// See: https://www.w3.org/TR/css-color-4/#hsl-to-rgb
+func hslToRGB(h, s, l float64) (float64, float64, float64) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * min(l, 1-l)
+		return l - a*max(-1, min(k-3, 9-k, 1))
+	}
+	return f(0), f(8), f(4)
+}
+
+// rgbToHSL converts red, green, and blue (0-1) to hue (degrees), saturation
+// and lightness (0-1).
+//
+// See: https://www.w3.org/TR/css-color-4/#rgb-to-hsl
+func rgbToHSL(r, g, b float64) (float64, float64, float64) {
+	hi, lo := max(r, g, b), min(r, g, b)
+	var h, s float64
+	l := (lo + hi) / 2
+	if d := hi - lo; d != 0 {
+		if l != 0 && l != 1 {
+			s = (hi - l) / min(l, 1-l)
+		}
+		switch hi {
+		case r:
+			h = (g-b)/d + 6
+		case g:
+			h = (b-r)/d + 2
+		case b:
+			h = (r-g)/d + 4
+		}
+		h = math.Mod(h*60, 360)
+	}
+	return h, s, l
+}
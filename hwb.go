@@ -0,0 +1,102 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// HWB is a color in the HWB (hue, whiteness, blackness) color model, as
+// defined in the CSS Color Level 4 spec.
+//
+// H is the hue in degrees, and W, B, and A are the whiteness, blackness, and
+// alpha (0-1).
+type HWB struct {
+	H, W, B, A float64
+}
+
+// FromHWB converts a hwb string to a color, ex: hwb(210 20% 30%) or
+// hwb(210deg 20% 30% / 50%).
+//
+// Whiteness and blackness may be specified as percentages or as numbers
+// (0-100), and are clamped. When the sum of whiteness and blackness exceeds
+// 100%, they are normalized. See [HWB.Normalize].
+func FromHWB(s string) (Color, bool) {
+	c, err := options{}.parseHWB(s)
+	return c, err == nil
+}
+
+// parseHWB parses a hwb string.
+func (o options) parseHWB(s string) (Color, *ParseError) {
+	f, err := parseNamedFunc(s, "hwb")
+	if err != nil {
+		return Color{}, err
+	}
+	if err := f.expect(3, false); err != nil {
+		return Color{}, err
+	}
+	v, err := o.parseChannels(f, hslChannels)
+	if err != nil {
+		return Color{}, err
+	}
+	a, err := o.parseAlpha(f, false)
+	if err != nil {
+		return Color{}, err
+	}
+	return HWB{noneToZero(v[0]), clamp(noneToZero(v[1])/100, 0, 1), clamp(noneToZero(v[2])/100, 0, 1), a}.Color(), nil
+}
+
+// HWB returns the color as a [HWB].
+func (c Color) HWB() HWB {
+	r, g, b := float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff
+	h, _, _ := rgbToHSL(r, g, b)
+	return HWB{h, min(r, g, b), 1 - max(r, g, b), float64(c.A) / 0xff}
+}
+
+// AsHWB returns the color formatted as a hwb string, ex: hwb(210 20% 30%) or
+// hwb(210 20% 30% / 0.5).
+func (c Color) AsHWB() string {
+	v := c.HWB()
+	h, w, b := formatFloat(v.H, 2), formatFloat(v.W*100, 2), formatFloat(v.B*100, 2)
+	if c.A != 0xff {
+		return fmt.Sprintf("hwb(%s %s%% %s%% / %s)", h, w, b, formatFloat(v.A, 3))
+	}
+	return fmt.Sprintf("hwb(%s %s%% %s%%)", h, w, b)
+}
+
+// Normalize returns the normalized HWB, with the hue in the range 0-360, and
+// whiteness and blackness scaled proportionally when their sum exceeds 1.
+func (v HWB) Normalize() HWB {
+	if v.H = math.Mod(v.H, 360); v.H < 0 {
+		v.H += 360
+	}
+	if sum := v.W + v.B; sum > 1 {
+		v.W, v.B = v.W/sum, v.B/sum
+	}
+	return v
+}
+
+// Color converts the HWB to a [Color].
+func (v HWB) Color() Color {
+	v = v.Normalize()
+	r, g, b := hwbToRGB(v.H, v.W, v.B)
+	return New(toUint8(r), toUint8(g), toUint8(b), toUint8(v.A))
+}
+
+// RGBA satisfies the [color.Color] interface.
+func (v HWB) RGBA() (r, g, b, a uint32) {
+	return v.Color().RGBA()
+}
+
+// hwbToRGB converts hue (degrees), whiteness and blackness (0-1) to red,
+// green and blue (0-1).
+//
+// See: https://www.w3.org/TR/css-color-4/#hwb-to-rgb
+func hwbToRGB(h, w, b float64) (float64, float64, float64) {
+	if sum := w + b; sum >= 1 {
+		gray := w / sum
+		return gray, gray, gray
+	}
+	r, g, bl := hslToRGB(h, 1, 0.5)
+	f := 1 - w - b
+	return r*f + w, g*f + w, bl*f + w
+}
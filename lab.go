@@ -0,0 +1,198 @@
+package colors
+
+import (
+	"math"
+)
+
+// Lab is a color in the CIE Lab color space, using a D50 white point, as
+// defined in the CSS Color Level 4 spec.
+//
+// L is the lightness (0-100), A and B are the green-red and blue-yellow
+// axes, and Alpha is the alpha (0-1).
+type Lab struct {
+	L, A, B, Alpha float64
+}
+
+// LCh is a color in the CIE LCh color space, the polar form of [Lab].
+//
+// L is the lightness (0-100), C is the chroma, H is the hue in degrees, and
+// Alpha is the alpha (0-1).
+type LCh struct {
+	L, C, H, Alpha float64
+}
+
+// FromLab converts a lab string to a color, ex: lab(54.29 80.8 69.89) or
+// lab(54.29% 64.64% 55.91% / 0.5).
+//
+// Lightness may be a number (0-100) or a percentage, and the a and b axes
+// may be numbers or percentages, where 100% is 125. Colors outside of the
+// sRGB gamut are gamut mapped. See [FloatColor.Color].
+func FromLab(s string) (Color, bool) {
+	c, err := options{}.parseLab(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// FromLCh converts a lch string to a color, ex: lch(54.29 106.84 40.85) or
+// lch(54.29% 71.23% 40.85deg / 0.5).
+//
+// Lightness may be a number (0-100) or a percentage, chroma may be a number
+// or a percentage, where 100% is 150, and the hue may be specified with any
+// of the units supported by [FromHSL]. Colors outside of the sRGB gamut are
+// gamut mapped. See [FloatColor.Color].
+func FromLCh(s string) (Color, bool) {
+	c, err := options{}.parseLCh(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// Lab returns the color as a [Lab].
+func (c Color) Lab() Lab {
+	v := xyzToLab(xyz65ToXYZ50.mul(srgbToXYZ65(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff)))
+	return Lab{v[0], v[1], v[2], float64(c.A) / 0xff}
+}
+
+// LCh returns the color as a [LCh].
+func (c Color) LCh() LCh {
+	return c.Lab().LCh()
+}
+
+// LCh converts the Lab to a [LCh].
+func (v Lab) LCh() LCh {
+	l, c, h := toPolar(v.L, v.A, v.B)
+	return LCh{l, c, h, v.Alpha}
+}
+
+// Color converts the Lab to a [Color], and reports whether the color was
+// within the sRGB gamut. Out of gamut channels are clipped.
+func (v Lab) Color() (Color, bool) {
+	r, g, b := xyz65ToSRGB(xyz50ToXYZ65.mul(labToXYZ([3]float64{v.L, v.A, v.B})))
+	return New(toUint8(r), toUint8(g), toUint8(b), toUint8(v.Alpha)), inGamut(r, g, b)
+}
+
+// RGBA satisfies the [color.Color] interface.
+func (v Lab) RGBA() (r, g, b, a uint32) {
+	c, _ := v.Color()
+	return c.RGBA()
+}
+
+// Lab converts the LCh to a [Lab].
+func (v LCh) Lab() Lab {
+	l, a, b := fromPolar(v.L, v.C, v.H)
+	return Lab{l, a, b, v.Alpha}
+}
+
+// Color converts the LCh to a [Color], and reports whether the color was
+// within the sRGB gamut. Out of gamut channels are clipped.
+func (v LCh) Color() (Color, bool) {
+	return v.Lab().Color()
+}
+
+// RGBA satisfies the [color.Color] interface.
+func (v LCh) RGBA() (r, g, b, a uint32) {
+	return v.Lab().RGBA()
+}
+
+// parseLab parses a lab string.
+func (o options) parseLab(s string) (FloatColor, *ParseError) {
+	return o.parseLabFunc(s, "lab", SpaceLab, [3]channel{{100, 0, 100}, {125, math.Inf(-1), math.Inf(1)}, {125, math.Inf(-1), math.Inf(1)}})
+}
+
+// parseLCh parses a lch string.
+func (o options) parseLCh(s string) (FloatColor, *ParseError) {
+	return o.parseLabFunc(s, "lch", SpaceLCh, [3]channel{{100, 0, 100}, {150, 0, math.Inf(1)}, hue})
+}
+
+// parseLabFunc parses a lab, lch, oklab, or oklch string for the named
+// function. Lightness is clamped to 0-100%, and chroma is clamped to 0 or
+// greater.
+func (o options) parseLabFunc(s, name string, space Space, chans [3]channel) (FloatColor, *ParseError) {
+	f, err := parseNamedFunc(s, name)
+	if err != nil {
+		return FloatColor{}, err
+	}
+	if err := f.expect(3, false); err != nil {
+		return FloatColor{}, err
+	}
+	c := FloatColor{Space: space}
+	if c.Channels, err = o.parseChannels(f, chans); err != nil {
+		return FloatColor{}, err
+	}
+	if c.Alpha, err = o.parseAlpha(f, false); err != nil {
+		return FloatColor{}, err
+	}
+	c.Channels[0] = clamp(c.Channels[0], 0, chans[0].ref)
+	if space.Polar() != -1 {
+		c.Channels[1] = max(c.Channels[1], 0)
+	}
+	return c, nil
+}
+
+// xyzToLab converts CIE XYZ (D50) to CIE Lab.
+//
+// See: https://www.w3.org/TR/css-color-4/#color-conversion-code
+func xyzToLab(v [3]float64) [3]float64 {
+	var f [3]float64
+	for i := range v {
+		if x := v[i] / d50[i]; x > labEpsilon {
+			f[i] = math.Cbrt(x)
+		} else {
+			f[i] = (labKappa*x + 16) / 116
+		}
+	}
+	return [3]float64{116*f[1] - 16, 500 * (f[0] - f[1]), 200 * (f[1] - f[2])}
+}
+
+// labToXYZ converts CIE Lab to CIE XYZ (D50).
+//
+// See: https://www.w3.org/TR/css-color-4/#color-conversion-code
+func labToXYZ(v [3]float64) [3]float64 {
+	var f [3]float64
+	f[1] = (v[0] + 16) / 116
+	f[0] = v[1]/500 + f[1]
+	f[2] = f[1] - v[2]/200
+	var xyz [3]float64
+	if x := f[0] * f[0] * f[0]; x > labEpsilon {
+		xyz[0] = x
+	} else {
+		xyz[0] = (116*f[0] - 16) / labKappa
+	}
+	if v[0] > labKappa*labEpsilon {
+		xyz[1] = f[1] * f[1] * f[1]
+	} else {
+		xyz[1] = v[0] / labKappa
+	}
+	if z := f[2] * f[2] * f[2]; z > labEpsilon {
+		xyz[2] = z
+	} else {
+		xyz[2] = (116*f[2] - 16) / labKappa
+	}
+	return [3]float64{xyz[0] * d50[0], xyz[1] * d50[1], xyz[2] * d50[2]}
+}
+
+// toPolar converts rectangular l, a, b coordinates to polar l, c, h, with the
+// hue in degrees (0-360).
+func toPolar(l, a, b float64) (float64, float64, float64) {
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, math.Hypot(a, b), h
+}
+
+// fromPolar converts polar l, c, h coordinates, with the hue in degrees, to
+// rectangular l, a, b.
+func fromPolar(l, c, h float64) (float64, float64, float64) {
+	h = h * math.Pi / 180
+	return l, c * math.Cos(h), c * math.Sin(h)
+}
+
+// CIE Lab constants.
+const (
+	labEpsilon = 216.0 / 24389
+	labKappa   = 24389.0 / 27
+)
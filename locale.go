@@ -0,0 +1,342 @@
+package colors
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Locale is a table of localized color names for a language.
+//
+// Use [Registry.RegisterLocale] to parse localized names, and
+// [NamedColor.Localized] or [Registry.Localized] to display them.
+type Locale struct {
+	lang string
+	// names are the localized names, keyed by the normalized color name.
+	// The first name is the display name.
+	names map[NamedColor][]string
+	// lookup are the color names, keyed by the normalized localized name.
+	// See [localeKey].
+	lookup map[string]NamedColor
+}
+
+// LoadLocale loads a locale for the language from r, ex: de or pt-BR.
+//
+// Each line of the translation table is a color name, followed by = and the
+// localized names, separated by commas, where the first name is the display
+// name, ex:
+//
+//	red = rot
+//	gray = 灰色, グレー, はいいろ
+//
+// Blank lines, and lines starting with # are ignored. Color names are
+// normalized as with [FromName], and localized names as with
+// [Locale.Lookup].
+func LoadLocale(lang string, r io.Reader) (*Locale, error) {
+	l := &Locale{
+		lang:   langKey(lang),
+		names:  make(map[NamedColor][]string),
+		lookup: make(map[string]NamedColor),
+	}
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		str := strings.TrimSpace(s.Text())
+		if str == "" || str[0] == '#' {
+			continue
+		}
+		name, v, ok := strings.Cut(str, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing =", line)
+		}
+		n := nameKey(name)
+		switch _, ok := l.names[n]; {
+		case n == "":
+			return nil, fmt.Errorf("line %d: invalid name %q", line, strings.TrimSpace(name))
+		case ok:
+			return nil, fmt.Errorf("line %d: duplicate name %q", line, strings.TrimSpace(name))
+		}
+		for _, s := range strings.Split(v, ",") {
+			s = strings.TrimSpace(s)
+			key := localeKey(s)
+			if key == "" {
+				return nil, fmt.Errorf("line %d: invalid localized name %q", line, s)
+			}
+			if _, ok := l.lookup[key]; ok {
+				return nil, fmt.Errorf("line %d: duplicate localized name %q", line, s)
+			}
+			l.names[n] = append(l.names[n], s)
+			l.lookup[key] = n
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LoadLocales loads the locales from the files in fsys matching the pattern,
+// such as an [embed.FS], where the language is each file's name without its
+// extension, ex: locales/de.txt. See [LoadLocale].
+func LoadLocales(fsys fs.FS, pattern string) ([]*Locale, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	var v []*Locale
+	for _, file := range files {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		name := path.Base(file)
+		l, err := LoadLocale(strings.TrimSuffix(name, path.Ext(name)), f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		v = append(v, l)
+	}
+	return v, nil
+}
+
+// Lang returns the locale's language.
+func (l *Locale) Lang() string {
+	return l.lang
+}
+
+// Name returns the localized display name for the color name, ex: rot for
+// red.
+func (l *Locale) Name(name NamedColor) (string, bool) {
+	if v := l.names[nameKey(string(name))]; len(v) != 0 {
+		return v[0], true
+	}
+	return "", false
+}
+
+// Names returns the localized names for the color name, the first of which
+// is the display name.
+func (l *Locale) Names(name NamedColor) []string {
+	return append([]string(nil), l.names[nameKey(string(name))]...)
+}
+
+// Lookup returns the color name for the localized name, ex: red for rot.
+//
+// Localized names are normalized using Unicode case folding, and ignore
+// whitespace, punctuation, and symbols. The Latin-1 Supplement and Latin
+// Extended-A letters with diacritics match their base letters (ex: marron
+// matches marrón, and cervena matches červená), as do Latin letters followed
+// by combining diacritics, as in decomposed (NFD) text. ß matches ss, full
+// width forms match their ASCII equivalents, katakana matches hiragana (ex:
+// レッド matches れっど), and kana followed by combining voiced marks match
+// their composed forms.
+func (l *Locale) Lookup(s string) (NamedColor, bool) {
+	n, ok := l.lookup[localeKey(s)]
+	return n, ok
+}
+
+// Locales returns the languages of the built in locales, sorted.
+func Locales() []string {
+	v := make([]string, 0, len(builtinLocales))
+	for lang := range builtinLocales {
+		v = append(v, lang)
+	}
+	sort.Strings(v)
+	return v
+}
+
+// LookupLocale returns the built in locale for the language, ex: de, es, or
+// ja. Regional languages fall back to their base language, ex: de-AT to de.
+func LookupLocale(lang string) (*Locale, bool) {
+	return findLocale(builtinLocales, lang)
+}
+
+// RegisterLocale registers the locale with the [DefaultRegistry]. See
+// [Registry.RegisterLocale].
+func RegisterLocale(l *Locale) {
+	DefaultRegistry.RegisterLocale(l)
+}
+
+// Localized returns the localized display name for the named color, using
+// the [DefaultRegistry], ex: rot for red with de. Returns the name when there
+// is no localized name. See [Registry.Localized].
+func (n NamedColor) Localized(lang string) string {
+	return DefaultRegistry.Localized(n, lang)
+}
+
+// RegisterLocale registers the locale, such that its localized names are
+// found by [Registry.FromName], and are used by [Registry.Localized].
+// Replaces any locale registered for the same language.
+//
+// Localized names are looked up after all other names, and in the sort order
+// of the registered languages.
+func (r *Registry) RegisterLocale(l *Locale) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.locales == nil {
+		r.locales = make(map[string]*Locale)
+	}
+	if _, ok := r.locales[l.lang]; !ok {
+		r.langs = append(r.langs, l.lang)
+		sort.Strings(r.langs)
+	}
+	r.locales[l.lang] = l
+}
+
+// Localized returns the localized display name for the named color, ex: rot
+// for red with de. Uses the locale registered for the language, or the built
+// in locale, and falls back to the base language (ex: de-AT to de), and to
+// the canonical name for the color (ex: gray for grey). Returns the name
+// when there is no localized name.
+func (r *Registry) Localized(name NamedColor, lang string) string {
+	r.mu.RLock()
+	l, ok := findLocale(r.locales, lang)
+	r.mu.RUnlock()
+	if !ok {
+		if l, ok = LookupLocale(lang); !ok {
+			return string(name)
+		}
+	}
+	if s, ok := l.Name(name); ok {
+		return s
+	}
+	if s, ok := l.Name(r.Canonical(name)); ok {
+		return s
+	}
+	return string(name)
+}
+
+// fromLocale returns the registered name for the localized name. The caller
+// must hold the lock.
+func (r *Registry) fromLocale(s string) (NamedColor, bool) {
+	if len(r.langs) == 0 {
+		return "", false
+	}
+	key := localeKey(s)
+	for _, lang := range r.langs {
+		if n, ok := r.locales[lang].lookup[key]; ok {
+			if n, ok := r.names[n]; ok {
+				return n, true
+			}
+		}
+	}
+	return "", false
+}
+
+// findLocale finds the locale for the language, falling back to the base
+// language.
+func findLocale(locales map[string]*Locale, lang string) (*Locale, bool) {
+	lang = langKey(lang)
+	for {
+		if l, ok := locales[lang]; ok {
+			return l, true
+		}
+		i := strings.LastIndexByte(lang, '-')
+		if i == -1 {
+			return nil, false
+		}
+		lang = lang[:i]
+	}
+}
+
+// langKey returns the lookup key for the language, ex: pt-br for pt_BR.
+func langKey(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+}
+
+// localeKey returns the lookup key for the localized name s. See
+// [Locale.Lookup].
+func localeKey(s string) string {
+	v := make([]rune, 0, len(s))
+	for _, c := range s {
+		switch {
+		case 0xff01 <= c && c <= 0xff5e:
+			// full width forms
+			c -= 0xff01 - '!'
+		case 0x30a1 <= c && c <= 0x30f6:
+			// katakana
+			c -= 0x60
+		}
+		c = unicode.ToLower(c)
+		switch {
+		case unicode.IsMark(c):
+			// combining marks, as in decomposed (NFD) text, are composed
+			// with kana, ignored after Latin letters, and otherwise kept
+			n := len(v)
+			if n == 0 {
+				break
+			}
+			if x, ok := composeKana(v[n-1], c); ok {
+				v[n-1] = x
+			} else if !unicode.Is(unicode.Latin, v[n-1]) {
+				v = append(v, c)
+			}
+		case c == 'ß':
+			v = append(v, 's', 's')
+		case c == 'æ':
+			v = append(v, 'a', 'e')
+		case c == 'œ':
+			v = append(v, 'o', 'e')
+		case c == 'ĳ':
+			v = append(v, 'i', 'j')
+		case 0xe0 <= c && c <= 0x17f:
+			if x := latinFold[c-0xe0]; x != '_' {
+				v = append(v, rune(x))
+			}
+		case unicode.IsLetter(c), unicode.IsDigit(c):
+			v = append(v, c)
+		}
+	}
+	return string(v)
+}
+
+// latinFold are the base letters of the Latin-1 Supplement and Latin
+// Extended-A letters (U+00E0 - U+017F), where _ is not a letter, or is
+// handled separately.
+const latinFold = "aaaaaa_ceeeeiiiidnooooo_ouuuuyty" + // U+00E0
+	"aaaaaaccccccccddddeeeeeeeeeegggggggghhhhiiiiiiiiii__jjkkkllllllllll" +
+	"nnnnnnnnnoooooo__rrrrrrssssssssttttttuuuuuuuuuuuuwwyyyzzzzzzs"
+
+// composeKana returns the kana composed with the voiced (U+3099) or
+// semi-voiced (U+309A) combining mark, ex: か and U+3099 is が.
+func composeKana(base, mark rune) (rune, bool) {
+	var pairs string
+	switch mark {
+	case 0x3099:
+		pairs = "かがきぎくぐけげこごさざしじすずせぜそぞただちぢつづてでとどはばひびふぶへべほぼうゔ"
+	case 0x309a:
+		pairs = "はぱひぴふぷへぺほぽ"
+	default:
+		return 0, false
+	}
+	var prev rune
+	i := 0
+	for _, r := range pairs {
+		if i%2 == 1 && prev == base {
+			return r, true
+		}
+		prev, i = r, i+1
+	}
+	return 0, false
+}
+
+// builtinLocales are the built in locales.
+var builtinLocales = make(map[string]*Locale)
+
+//go:embed locales/*.txt
+var localeFS embed.FS
+
+func init() {
+	locales, err := LoadLocales(localeFS, "locales/*.txt")
+	if err != nil {
+		panic(err)
+	}
+	for _, l := range locales {
+		builtinLocales[l.lang] = l
+	}
+}
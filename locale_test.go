@@ -0,0 +1,196 @@
+package colors
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocales(t *testing.T) {
+	if v, exp := Locales(), []string{"de", "es", "ja"}; !reflect.DeepEqual(v, exp) {
+		t.Fatalf("expected %v, got: %v", exp, v)
+	}
+	tests := []struct {
+		lang string
+		s    string
+		exp  NamedColor
+	}{
+		{"de", "rot", Red},
+		{"de", "Weiß", White},
+		{"de", "WEISS", White},
+		{"de", "dunkel-grün", Darkgreen},
+		{"de", "Dunkelgruen", ""},
+		{"es", "azul", Blue},
+		{"es", "Azul Marino", Navy},
+		{"es", "marron", Brown},
+		{"es", "café", Brown},
+		{"es", "ÍNDIGO", Indigo},
+		{"es", "marro\u0301n", Brown},
+		{"es", "I\u0301ndigo", Indigo},
+		{"ja", "赤", Red},
+		{"ja", "あか", Red},
+		{"ja", "レッド", Red},
+		{"ja", "れっど", Red},
+		{"ja", "グレー", Gray},
+		{"ja", "ダーク・グレー", Darkgray},
+		{"ja", "ク\u3099レー", Gray},
+		{"ja", "く\u3099れー", Gray},
+		{"ja", "クレー", ""},
+		{"ja", "ｒｅｄ", ""},
+		{"ja", "red", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.lang+"/"+test.s, func(t *testing.T) {
+			l, ok := LookupLocale(test.lang)
+			if !ok {
+				t.Fatalf("expected ok")
+			}
+			n, ok := l.Lookup(test.s)
+			switch {
+			case test.exp == "" && ok:
+				t.Errorf("expected not ok, got: %s", n)
+			case test.exp != "" && n != test.exp:
+				t.Errorf("expected %s, got: %s", test.exp, n)
+			}
+		})
+	}
+}
+
+func TestLocaleKey(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"Marrón", "marron"},
+		{"marro\u0301n", "marron"},
+		{"červená", "cervena"},
+		{"c\u030cerve\u0301na", "cervena"},
+		{"RŮŽOVÁ", "ruzova"},
+		{"Łódź", "lodz"},
+		{"ąř", "ar"},
+		{"Straße", "strasse"},
+		{"ĳs", "ijs"},
+		{"ｒｅｄ", "red"},
+		{"レッド", "れっど"},
+		{"ハ\u309aフ\u3099", "ぱぶ"},
+		{"\u0301a", "a"},
+	}
+	for _, test := range tests {
+		if v := localeKey(test.s); v != test.exp {
+			t.Errorf("%q: expected %q, got: %q", test.s, test.exp, v)
+		}
+	}
+}
+
+func TestLocalized(t *testing.T) {
+	tests := []struct {
+		n    NamedColor
+		lang string
+		exp  string
+	}{
+		{Red, "de", "Rot"},
+		{Red, "de-AT", "Rot"},
+		{Red, "de_CH", "Rot"},
+		{Red, "ja", "赤"},
+		{Red, "es", "rojo"},
+		{Grey, "de", "Grau"},
+		{Darkslategrey, "de", "darkslategrey"},
+		{"Light Gray", "es", "gris claro"},
+		{Red, "fr", "red"},
+		{Red, "", "red"},
+	}
+	for _, test := range tests {
+		if s := test.n.Localized(test.lang); s != test.exp {
+			t.Errorf("%s %s: expected %q, got: %q", test.n, test.lang, test.exp, s)
+		}
+	}
+}
+
+func TestRegistryLocale(t *testing.T) {
+	r := NewRegistry()
+	for _, lang := range []string{"de", "ja", "es"} {
+		l, _ := LookupLocale(lang)
+		r.RegisterLocale(l)
+	}
+	for _, s := range []string{"rot", "赤", "あか", "rojo", "Rot", "red"} {
+		c, err := Parse(s, WithRegistry(r))
+		if err != nil {
+			t.Fatalf("%q: expected no error, got: %v", s, err)
+		}
+		if c.Name() != "red" {
+			t.Errorf("%q: expected red, got: %q", s, c.Name())
+		}
+	}
+	if _, ok := FromName("rot"); ok {
+		t.Errorf("expected not ok")
+	}
+	if _, ok := r.Clone().FromName("azul"); !ok {
+		t.Errorf("expected ok")
+	}
+	// custom locale, replacing the built in locale
+	l, err := LoadLocale("de", strings.NewReader("# test\n\nbrand-primary = Hausfarbe, Primärfarbe\nred = Knallrot\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	r.RegisterLocale(l)
+	if _, ok := r.FromName("primaerfarbe"); ok {
+		t.Errorf("expected not ok")
+	}
+	if _, ok := r.FromName("primärfarbe"); ok {
+		t.Errorf("expected not ok")
+	}
+	r.RegisterName("brand-primary", Color{0x12, 0x34, 0x56, 0xff, ""})
+	if c, ok := r.FromName("Primärfarbe"); !ok || c.Name() != "brand-primary" {
+		t.Errorf("expected brand-primary, got: %v %t", c, ok)
+	}
+	if s := r.Localized("brand_primary", "de"); s != "Hausfarbe" {
+		t.Errorf("expected Hausfarbe, got: %q", s)
+	}
+	if s := r.Localized(Red, "de"); s != "Knallrot" {
+		t.Errorf("expected Knallrot, got: %q", s)
+	}
+	if _, ok := r.FromName("grün"); ok {
+		t.Errorf("expected not ok")
+	}
+	if v := l.Names("brand primary"); !reflect.DeepEqual(v, []string{"Hausfarbe", "Primärfarbe"}) {
+		t.Errorf("expected names, got: %v", v)
+	}
+}
+
+func TestLoadLocale(t *testing.T) {
+	tests := []struct {
+		s   string
+		err string
+	}{
+		{"red rot", "line 1: missing ="},
+		{"= rot", `line 1: invalid name ""`},
+		{"red = rot\nRed = rot", `line 2: duplicate name "Red"`},
+		{"red = rot,", `line 1: invalid localized name ""`},
+		{"red = rot\nblue = Rot", `line 2: duplicate localized name "Rot"`},
+	}
+	for _, test := range tests {
+		if _, err := LoadLocale("de", strings.NewReader(test.s)); err == nil || err.Error() != test.err {
+			t.Errorf("%q: expected error %q, got: %v", test.s, test.err, err)
+		}
+	}
+	fsys := fstest.MapFS{
+		"i18n/fr.txt":    {Data: []byte("red = rouge\n")},
+		"i18n/pt-BR.txt": {Data: []byte("red = vermelho\n")},
+		"i18n/README":    {Data: []byte("not a locale")},
+	}
+	locales, err := LoadLocales(fsys, "i18n/*.txt")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if len(locales) != 2 || locales[0].Lang() != "fr" || locales[1].Lang() != "pt-br" {
+		t.Fatalf("expected fr and pt-br, got: %v", locales)
+	}
+	if s, _ := locales[1].Name(Red); s != "vermelho" {
+		t.Errorf("expected vermelho, got: %q", s)
+	}
+	fsys["i18n/es.txt"] = &fstest.MapFile{Data: []byte("red")}
+	if _, err := LoadLocales(fsys, "i18n/*.txt"); err == nil || err.Error() != "i18n/es.txt: line 1: missing =" {
+		t.Errorf("expected error, got: %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+package colors
+
+import (
+	"math"
+	"strings"
+)
+
+// HueMethod is a hue interpolation method, used when mixing colors in a polar
+// color space.
+//
+// See: https://www.w3.org/TR/css-color-4/#hue-interpolation
+type HueMethod int
+
+// Hue interpolation methods.
+const (
+	HueShorter HueMethod = iota
+	HueLonger
+	HueIncreasing
+	HueDecreasing
+)
+
+// ParseHueMethod parses a CSS hue interpolation method name, ex: shorter.
+func ParseHueMethod(s string) (HueMethod, bool) {
+	for i, name := range hueMethods {
+		if strings.EqualFold(s, name) {
+			return HueMethod(i), true
+		}
+	}
+	return 0, false
+}
+
+// String satisfies the [fmt.Stringer] interface.
+func (m HueMethod) String() string {
+	if 0 <= m && int(m) < len(hueMethods) {
+		return hueMethods[m]
+	}
+	return "shorter"
+}
+
+// hueMethods are the hue interpolation method names.
+var hueMethods = []string{
+	"shorter",
+	"longer",
+	"increasing",
+	"decreasing",
+}
+
+// Mix mixes a and b in the color space, where t (0-1) is the proportion of b
+// in the result, interpolating hues using the shorter hue method. Mix(a, b,
+// SpaceOKLCH, 0.6) is equivalent to the CSS color-mix(in oklch, a 40%, b).
+//
+// See [MixFloat] for mixing colors with full precision or other hue
+// interpolation methods.
+func Mix(a, b Color, space Space, t float64) Color {
+	return MixFloat(a.Float(), b.Float(), space, HueShorter, t).Color()
+}
+
+// MixFloat mixes a and b in the color space, where t (0-1) is the proportion
+// of b in the result, interpolating hues with the hue method when the color
+// space is polar. The result is in the color space.
+//
+// Colors are interpolated with premultiplied alpha, and missing (NaN)
+// channels take the value of the other color's channel.
+//
+// See: https://www.w3.org/TR/css-color-4/#interpolation
+func MixFloat(a, b FloatColor, space Space, hue HueMethod, t float64) FloatColor {
+	a, b = toInterpolation(a, space), toInterpolation(b, space)
+	h := space.Polar()
+	// resolve missing channels and alpha
+	for i := range a.Channels {
+		a.Channels[i], b.Channels[i] = carry(a.Channels[i], b.Channels[i])
+	}
+	a.Alpha, b.Alpha = carry(a.Alpha, b.Alpha)
+	if h != -1 && !math.IsNaN(a.Channels[h]) {
+		a.Channels[h], b.Channels[h] = fixHues(a.Channels[h], b.Channels[h], hue)
+	}
+	// interpolate, premultiplying non-hue channels
+	c := FloatColor{Space: space, Alpha: lerp(noneToZero(a.Alpha), noneToZero(b.Alpha), t)}
+	for i := range c.Channels {
+		x, y := a.Channels[i], b.Channels[i]
+		if i == h {
+			if c.Channels[i] = math.Mod(lerp(x, y, t), 360); c.Channels[i] < 0 {
+				c.Channels[i] += 360
+			}
+			continue
+		}
+		if c.Channels[i] = lerp(x*noneToZero(a.Alpha), y*noneToZero(b.Alpha), t); c.Alpha != 0 {
+			c.Channels[i] /= c.Alpha
+		}
+	}
+	return c
+}
+
+// FromColorMix converts a CSS color-mix() string to a color, ex:
+// color-mix(in oklch, red 40%, blue) or color-mix(in hsl longer hue, red,
+// blue).
+//
+// Supports all of the color spaces in [Space], and the hue interpolation
+// methods in [HueMethod]. Mixed colors may be any color supported by
+// [ParseFloatColor], including nested color-mix() functions. Colors outside
+// of the sRGB gamut are gamut mapped. See [FloatColor.Color].
+//
+// See: https://www.w3.org/TR/css-color-5/#color-mix
+func FromColorMix(s string) (Color, bool) {
+	c, err := options{}.parseColorMix(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// parseColorMix parses a CSS color-mix() string.
+func (o options) parseColorMix(s string) (FloatColor, *ParseError) {
+	inner, off, err := funcArgs(s, "color-mix")
+	if err != nil {
+		return FloatColor{}, err
+	}
+	var args [4]string
+	var offs [4]int
+	if n, ok := split(inner, ',', args[:], offs[:]); !ok || n != 3 {
+		return FloatColor{}, errorf(off, "expected an interpolation method and 2 colors")
+	}
+	// interpolation method, ex: in oklch longer hue
+	var toks [4]string
+	var toffs [4]int
+	n, ok := split(args[0], ' ', toks[:], toffs[:])
+	if !ok || (n != 2 && n != 4) || !strings.EqualFold(toks[0], "in") {
+		return FloatColor{}, errorf(off+offs[0], "invalid interpolation method %q", args[0])
+	}
+	space, ok := ParseSpace(toks[1])
+	if !ok {
+		return FloatColor{}, errorf(off+offs[0]+toffs[1], "unknown color space %q", toks[1])
+	}
+	hue := HueShorter
+	if n == 4 {
+		if hue, ok = ParseHueMethod(toks[2]); !ok || space.Polar() == -1 || !strings.EqualFold(toks[3], "hue") {
+			return FloatColor{}, errorf(off+offs[0], "invalid interpolation method %q", args[0])
+		}
+	}
+	// colors and percentages
+	var c [2]FloatColor
+	var p [2]float64
+	for i := range c {
+		if c[i], p[i], err = o.parseMixColor(args[i+1], i); err != nil {
+			return FloatColor{}, nested(err, off+offs[i+1])
+		}
+	}
+	// normalize percentages
+	p1, p2 := p[0], p[1]
+	switch {
+	case math.IsNaN(p1) && math.IsNaN(p2):
+		p1, p2 = 0.5, 0.5
+	case math.IsNaN(p2):
+		p2 = 1 - p1
+	case math.IsNaN(p1):
+		p1 = 1 - p2
+	}
+	sum := p1 + p2
+	if sum == 0 {
+		return FloatColor{}, errorf(off, "percentages must not sum to 0%%")
+	}
+	v := MixFloat(c[0], c[1], space, hue, p2/sum)
+	if sum < 1 {
+		v.Alpha *= sum
+	}
+	return v, nil
+}
+
+// parseMixColor parses the i'th color-mix() color and its optional
+// percentage (0-1) in s, ex: red 40% or 40% red. The percentage is NaN when
+// not specified.
+func (o options) parseMixColor(s string, i int) (FloatColor, float64, *ParseError) {
+	var toks [3]string
+	n, ok := split(s, ' ', toks[:], nil)
+	if !ok || n == 0 {
+		return FloatColor{}, 0, errorf(0, "invalid color %d", i+1)
+	}
+	p, str, off := math.NaN(), s, 0
+	for j, tok := range []string{toks[0], toks[n-1]} {
+		if n == 1 || tok[len(tok)-1] != '%' || numberLen(tok) != len(tok)-1 {
+			continue
+		}
+		v, _, ok := parseNumber(tok, 1, true)
+		if !ok || v < 0 || v > 1 || !math.IsNaN(p) {
+			return FloatColor{}, 0, errorf(0, "invalid percentage for color %d, expected 0-100%%", i+1)
+		}
+		if p = v; j == 0 {
+			str, off = str[len(tok):], len(tok)
+		} else {
+			str = str[:len(str)-len(tok)]
+		}
+	}
+	c, err := o.parseFloat(str)
+	if err != nil {
+		return FloatColor{}, 0, nested(err, off)
+	}
+	return c, p, nil
+}
+
+// toInterpolation converts c to the interpolation color space, carrying
+// forward missing channels to analogous channels, and marking powerless hues
+// as missing.
+//
+// See: https://www.w3.org/TR/css-color-4/#interpolation-missing
+func toInterpolation(c FloatColor, space Space) FloatColor {
+	if c.Space == space {
+		return c
+	}
+	v := c.Convert(space)
+	src, dst := analogous(c.Space), analogous(space)
+	for i, x := range c.Channels {
+		for j := range dst {
+			if math.IsNaN(x) && src[i] != 0 && src[i] == dst[j] {
+				v.Channels[j] = math.NaN()
+			}
+		}
+	}
+	// powerless hues
+	const eps = 0.00001
+	switch space {
+	case SpaceHSL:
+		if math.Abs(v.Channels[1]) < eps*100 {
+			v.Channels[0] = math.NaN()
+		}
+	case SpaceHWB:
+		if v.Channels[1]+v.Channels[2] >= 100-eps {
+			v.Channels[0] = math.NaN()
+		}
+	case SpaceLCh, SpaceOKLCH:
+		if v.Channels[1] < eps {
+			v.Channels[2] = math.NaN()
+		}
+	}
+	return v
+}
+
+// analogous returns the analogous channel categories for the color space.
+//
+// See: https://www.w3.org/TR/css-color-4/#analogous-components
+func analogous(space Space) [3]byte {
+	switch space {
+	case SpaceHSL:
+		return [3]byte{'h', 'c', 'l'}
+	case SpaceHWB:
+		return [3]byte{'h', 0, 0}
+	case SpaceLab, SpaceOKLab:
+		return [3]byte{'l', 'a', 'b'}
+	case SpaceLCh, SpaceOKLCH:
+		return [3]byte{'l', 'c', 'h'}
+	}
+	return [3]byte{'R', 'G', 'B'}
+}
+
+// carry returns a, b, where a missing (NaN) value takes the value of the
+// other.
+func carry(a, b float64) (float64, float64) {
+	switch {
+	case math.IsNaN(a):
+		return b, b
+	case math.IsNaN(b):
+		return a, a
+	}
+	return a, b
+}
+
+// fixHues adjusts hues a and b (degrees) for interpolation with the hue
+// method.
+//
+// See: https://www.w3.org/TR/css-color-4/#hue-interpolation
+func fixHues(a, b float64, hue HueMethod) (float64, float64) {
+	if a = math.Mod(a, 360); a < 0 {
+		a += 360
+	}
+	if b = math.Mod(b, 360); b < 0 {
+		b += 360
+	}
+	switch d := b - a; hue {
+	case HueLonger:
+		switch {
+		case 0 < d && d < 180:
+			a += 360
+		case -180 < d && d <= 0:
+			b += 360
+		}
+	case HueIncreasing:
+		if d < 0 {
+			b += 360
+		}
+	case HueDecreasing:
+		if d > 0 {
+			a += 360
+		}
+	default:
+		switch {
+		case d > 180:
+			a += 360
+		case d < -180:
+			b += 360
+		}
+	}
+	return a, b
+}
+
+// lerp linearly interpolates a and b.
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
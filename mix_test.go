@@ -0,0 +1,137 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestMix(t *testing.T) {
+	tests := []struct {
+		a, b  Color
+		space Space
+		t     float64
+		exp   color.NRGBA
+	}{
+		{Red.Color(), Blue.Color(), SpaceSRGB, 0, color.NRGBA{255, 0, 0, 255}},
+		{Red.Color(), Blue.Color(), SpaceSRGB, 1, color.NRGBA{0, 0, 255, 255}},
+		{Red.Color(), Blue.Color(), SpaceSRGB, 0.5, color.NRGBA{128, 0, 128, 255}},
+		{Red.Color(), Blue.Color(), SpaceSRGBLinear, 0.5, color.NRGBA{188, 0, 188, 255}},
+		{Red.Color(), Blue.Color(), SpaceHSL, 0.5, color.NRGBA{255, 0, 255, 255}},
+		{Red.Color(), Blue.Color(), SpaceHWB, 0.5, color.NRGBA{255, 0, 255, 255}},
+		{Black.Color(), White.Color(), SpaceLab, 0.5, color.NRGBA{119, 119, 119, 255}},
+		{Black.Color(), White.Color(), SpaceOKLab, 0.5, color.NRGBA{99, 99, 99, 255}},
+		{Black.Color(), White.Color(), SpaceXYZD65, 0.5, color.NRGBA{188, 188, 188, 255}},
+		{New(255, 0, 0, 0), Blue.Color(), SpaceSRGB, 0.5, color.NRGBA{0, 0, 255, 128}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			c := Mix(test.a, test.b, test.space, test.t)
+			if !c.Is(test.exp) {
+				t.Errorf("expected %v, got: %#v", test.exp, c)
+			}
+		})
+	}
+}
+
+func TestMixHue(t *testing.T) {
+	tests := []struct {
+		a, b float64
+		hue  HueMethod
+		exp  float64
+	}{
+		{0, 240, HueShorter, 300},
+		{0, 240, HueLonger, 120},
+		{0, 240, HueIncreasing, 120},
+		{0, 240, HueDecreasing, 300},
+		{240, 0, HueShorter, 300},
+		{240, 0, HueLonger, 120},
+		{240, 0, HueIncreasing, 300},
+		{240, 0, HueDecreasing, 120},
+		{10, 350, HueShorter, 0},
+		{10, 350, HueLonger, 180},
+		{-30, 390, HueShorter, 0},
+		{90, 90, HueLonger, 270},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			a, b := NewFloat(SpaceOKLCH, 0.5, 0.1, test.a, 1), NewFloat(SpaceOKLCH, 0.5, 0.1, test.b, 1)
+			c := MixFloat(a, b, SpaceOKLCH, test.hue, 0.5)
+			if h := c.Channels[2]; !near(0.0001, h, test.exp) && !near(0.0001, h, test.exp+360) {
+				t.Errorf("expected %v, got: %v", test.exp, h)
+			}
+		})
+	}
+}
+
+func TestMixMissing(t *testing.T) {
+	// white has a powerless hue, so the hue of blue is used
+	c := MixFloat(White.Color().Float(), Blue.Color().Float(), SpaceOKLCH, HueShorter, 0.5)
+	if h := Blue.Color().OKLCH().H; !near(0.001, c.Channels[2], h) {
+		t.Errorf("expected hue %v, got: %s", h, c)
+	}
+	// missing channels are carried forward
+	c = MixFloat(NewFloat(SpaceSRGB, nan(), 0, 1, 1), NewFloat(SpaceOKLab, 0.5, nan(), nan(), 1), SpaceSRGB, HueShorter, 0.5)
+	if c.Channels[0] <= 0 {
+		t.Errorf("expected red channel to be carried, got: %s", c)
+	}
+	c = MixFloat(NewFloat(SpaceLCh, nan(), 20, 30, 1), NewFloat(SpaceOKLCH, 0.5, nan(), nan(), 1), SpaceOKLCH, HueShorter, 0.5)
+	if !near(0.0001, c.Channels[0], 0.5) || !near(0.0001, c.Channels[1], NewFloat(SpaceLCh, 0, 20, 30, 1).Convert(SpaceOKLCH).Channels[1]) {
+		t.Errorf("expected lightness and chroma to be carried, got: %s", c)
+	}
+}
+
+func nan() float64 {
+	return math.NaN()
+}
+
+func TestParseColorMix(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp color.NRGBA
+	}{
+		{"color-mix(in srgb, red, blue)", color.NRGBA{128, 0, 128, 255}},
+		{"color-mix(in srgb, red 25%, blue)", color.NRGBA{64, 0, 191, 255}},
+		{"color-mix(in srgb, 25% red, blue 75%)", color.NRGBA{64, 0, 191, 255}},
+		{"color-mix(in srgb, red, blue 75%)", color.NRGBA{64, 0, 191, 255}},
+		{"color-mix(in srgb, red 75%, blue 75%)", color.NRGBA{128, 0, 128, 255}},
+		{"color-mix(in srgb, red 30%, blue 30%)", color.NRGBA{128, 0, 128, 153}},
+		{"color-mix(in srgb, rgb(255 0 0 / 0), blue)", color.NRGBA{0, 0, 255, 128}},
+		{"color-mix(in srgb, rgb(50% 0 0) 50%, rgb(0 0 50%))", color.NRGBA{64, 0, 64, 255}},
+		{"color-mix(in hsl, red, blue)", color.NRGBA{255, 0, 255, 255}},
+		{"color-mix(in hsl longer hue, red, blue)", color.NRGBA{0, 255, 0, 255}},
+		{"color-mix(in hsl increasing hue, red, blue)", color.NRGBA{0, 255, 0, 255}},
+		{"color-mix(in hsl decreasing hue, red, blue)", color.NRGBA{255, 0, 255, 255}},
+		{"color-mix(in xyz, black, white)", color.NRGBA{188, 188, 188, 255}},
+		{"color-mix(in srgb, color-mix(in srgb, red, blue), white)", color.NRGBA{191, 128, 191, 255}},
+		{"color-mix(in srgb, color(display-p3 0 1 0), black 100%)", color.NRGBA{0, 0, 0, 255}},
+		{"COLOR-MIX(IN SRGB, RED, BLUE)", color.NRGBA{128, 0, 128, 255}},
+		{"color-mix(in srgb, red 0%, blue 0%)", color.NRGBA{}},
+		{"color-mix(in srgb, red 120%, blue)", color.NRGBA{}},
+		{"color-mix(in srgb, red -20%, blue)", color.NRGBA{}},
+		{"color-mix(in srgb, red 20% 20%, blue)", color.NRGBA{}},
+		{"color-mix(in srgb red, blue)", color.NRGBA{}},
+		{"color-mix(in srgb, red)", color.NRGBA{}},
+		{"color-mix(in srgb, red, blue, white)", color.NRGBA{}},
+		{"color-mix(in srgb longer hue, red, blue)", color.NRGBA{}},
+		{"color-mix(in hsl longer, red, blue)", color.NRGBA{}},
+		{"color-mix(in unknown, red, blue)", color.NRGBA{}},
+		{"color-mix(srgb, red, blue)", color.NRGBA{}},
+		{"color-mix(in srgb, red, unknown)", color.NRGBA{}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			c, err := Parse(test.s)
+			switch {
+			case test.exp == (color.NRGBA{}) && err == nil:
+				t.Fatalf("expected error, got: %v", c)
+			case test.exp == (color.NRGBA{}):
+			case err != nil:
+				t.Fatalf("expected no error, got: %v", err)
+			case !c.Is(test.exp):
+				t.Errorf("expected %v, got: %#v", test.exp, c)
+			}
+		})
+	}
+}
@@ -3,34 +3,33 @@ package colors
 import (
 	"fmt"
 	"image/color"
+	"strings"
+
+	"github.com/kenshaw/colors/strcase"
 )
 
-// Register registers a named color.
+// Register registers a named color with the [DefaultRegistry]. The name is
+// found by [FromName] using its normalized form, such that a color registered
+// as slate-900 is found with slate-900, Slate_900, or slate900.
 func Register(n NamedColor, clr color.Color) {
-	c := color.NRGBAModel.Convert(clr).(color.NRGBA)
-	colors[n] = c
-	lookup[mapKey(c.R, c.G, c.B, c.A)] = n
+	DefaultRegistry.Register(n, clr)
 }
 
-// RegisterName registers a named color.
+// RegisterName registers a named color with the [DefaultRegistry].
 func RegisterName(s string, clr color.Color) {
-	Register(NamedColor(s), clr)
+	DefaultRegistry.Register(NamedColor(s), clr)
 }
 
-// Map returns a map of all named colors.
+// Map returns a map of all named colors in the [DefaultRegistry].
 func Map() map[NamedColor]Color {
-	m := make(map[NamedColor]Color, len(colors))
-	for k := range colors {
-		m[k] = k.Color()
-	}
-	return m
+	return DefaultRegistry.Map()
 }
 
-// MapString returns a map of all named colors.
+// MapString returns a map of all named colors in the [DefaultRegistry].
 func MapString() map[string]Color {
-	m := make(map[string]Color, len(colors))
-	for k := range colors {
-		m[string(k)] = k.Color()
+	m := make(map[string]Color)
+	for k, c := range DefaultRegistry.Map() {
+		m[string(k)] = c
 	}
 	return m
 }
@@ -192,15 +191,16 @@ const (
 
 // RGBA satisfies the [color.Color] interface.
 func (c NamedColor) RGBA() (r, g, b, a uint32) {
-	if c, ok := colors[c]; ok {
+	if c, ok := DefaultRegistry.color(c); ok {
 		return c.RGBA()
 	}
 	return
 }
 
-// Color returns a [Color] for the named color.
+// Color returns a [Color] for the named color, as registered with the
+// [DefaultRegistry].
 func (c NamedColor) Color() Color {
-	if v, ok := colors[c]; ok {
+	if v, ok := DefaultRegistry.color(c); ok {
 		return Color{v.R, v.G, v.B, v.A, c}
 	}
 	return Color{}
@@ -208,12 +208,25 @@ func (c NamedColor) Color() Color {
 
 // NRGBA returns the [color.NRGBA] for the named color.
 func (c NamedColor) NRGBA() color.NRGBA {
-	if v, ok := colors[c]; ok {
+	if v, ok := DefaultRegistry.color(c); ok {
 		return v
 	}
 	return color.NRGBAModel.Convert(c).(color.NRGBA)
 }
 
+// Aliases returns the other names registered with the [DefaultRegistry] for
+// the named color's color, sorted, ex: grey for gray. See
+// [Registry.Aliases].
+func (c NamedColor) Aliases() []NamedColor {
+	return DefaultRegistry.Aliases(c)
+}
+
+// Canonical returns the canonical name registered with the [DefaultRegistry]
+// for the named color's color, ex: gray for grey. See [Registry.Canonical].
+func (c NamedColor) Canonical() NamedColor {
+	return DefaultRegistry.Canonical(c)
+}
+
 // CMYK returns the color as a [color.CMYK].
 func (c NamedColor) CMYK() color.CMYK {
 	return color.CMYKModel.Convert(c).(color.CMYK)
@@ -234,6 +247,21 @@ func (c NamedColor) Format(f fmt.State, verb rune) {
 	c.Color().Format(f, verb)
 }
 
+// aliases are the alternate names of the CSS named colors with more than one
+// name, keyed by their canonical name. Canonical names are the CSS basic
+// color names (aqua and fuchsia), and the American spellings (gray).
+var aliases = map[NamedColor][]NamedColor{
+	Aqua:           {Cyan},
+	Fuchsia:        {Magenta},
+	Darkgray:       {Darkgrey},
+	Darkslategray:  {Darkslategrey},
+	Dimgray:        {Dimgrey},
+	Gray:           {Grey},
+	Lightgray:      {Lightgrey},
+	Lightslategray: {Lightslategrey},
+	Slategray:      {Slategrey},
+}
+
 // colors contains the named colors defined in the SVG 1.1 spec.
 //
 // Taken from golang.org/x/image/colornames/table.go
@@ -388,17 +416,34 @@ var colors = map[NamedColor]color.NRGBA{
 	Yellowgreen:          {0x9a, 0xcd, 0x32, 0xff}, // rgb(154, 205, 50)
 }
 
-// lookup is the lookup map.
-var lookup map[uint32]NamedColor
+// nameKey returns the lookup key for the name s. See [appendName].
+func nameKey(s string) NamedColor {
+	if key, ok := appendName(make([]byte, 0, len(s)), s, len(s)); ok {
+		return NamedColor(key)
+	}
+	return NamedColor(strings.ToLower(strings.TrimSpace(strcase.ForceCamelIdentifier(s))))
+}
 
-func init() {
-	lookup = make(map[uint32]NamedColor, len(colors))
-	for k, v := range colors {
-		lookup[mapKey(v.R, v.G, v.B, v.A)] = k
+// appendName appends the lookup key for the name s to dst, returning false
+// when s is not ASCII. The key is s lower cased, with all characters other
+// than letters and digits removed, as well as any digits preceding the first
+// letter, and is equivalent to the lower cased strcase.ForceCamelIdentifier
+// of s. Stops once the key is longer than limit.
+func appendName(dst []byte, s string, limit int) ([]byte, bool) {
+	for i := 0; i < len(s) && len(dst) <= limit; i++ {
+		switch c := s[i]; {
+		case c >= 0x80:
+			return nil, false
+		case 'A' <= c && c <= 'Z':
+			dst = append(dst, c+'a'-'A')
+		case 'a' <= c && c <= 'z', isDigit(c) && len(dst) != 0:
+			dst = append(dst, c)
+		}
 	}
+	return dst, true
 }
 
 // mapKey returns a map lookup key for r, g, b, a.
 func mapKey(r, g, b, a uint8) uint32 {
-	return uint32(r)<<24 | uint32(g)<<16 | uint32(b)<<8 | uint32(a)
+	return Color{R: r, G: g, B: b, A: a}.Uint32(OrderRGBA)
 }
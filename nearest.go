@@ -0,0 +1,466 @@
+package colors
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// Metric is a color distance metric.
+type Metric int
+
+// Metrics.
+const (
+	// MetricOKLab is the Euclidean distance in the OKLab color space
+	// (deltaEOK), where the distance between black and white is 1.
+	MetricOKLab Metric = iota
+	// MetricRGB is the Euclidean distance of the sRGB channels (0-255).
+	MetricRGB
+	// MetricCIEDE2000 is the CIEDE2000 color difference, in the CIE Lab
+	// color space (D50), where a distance of about 1 is a just noticeable
+	// difference.
+	MetricCIEDE2000
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (m Metric) String() string {
+	switch m {
+	case MetricRGB:
+		return "rgb"
+	case MetricCIEDE2000:
+		return "ciede2000"
+	}
+	return "oklab"
+}
+
+// scale returns the metric's distance between black and white, used to scale
+// alpha differences.
+func (m Metric) scale() float64 {
+	switch m {
+	case MetricRGB:
+		return 0xff
+	case MetricCIEDE2000:
+		return 100
+	}
+	return 1
+}
+
+// AlphaMode is how the alpha of colors is handled when measuring distance.
+type AlphaMode int
+
+// Alpha modes.
+const (
+	// AlphaInclude includes the difference in alpha in the distance, scaled
+	// to the metric, such that the difference between opaque and transparent
+	// is the same as between black and white.
+	AlphaInclude AlphaMode = iota
+	// AlphaIgnore ignores alpha.
+	AlphaIgnore
+	// AlphaComposite composites colors over the background before measuring
+	// distance. See [WithBackground].
+	AlphaComposite
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (mode AlphaMode) String() string {
+	switch mode {
+	case AlphaIgnore:
+		return "ignore"
+	case AlphaComposite:
+		return "composite"
+	}
+	return "include"
+}
+
+// NearestOption is a color distance option, for use with [Nearest] and
+// [Distance].
+type NearestOption func(*nearestOptions)
+
+// WithMetric is a color distance option to set the metric. The default
+// metric is [MetricOKLab].
+func WithMetric(metric Metric) NearestOption {
+	return func(o *nearestOptions) {
+		o.metric = metric
+	}
+}
+
+// WithAlphaMode is a color distance option to set how the alpha of colors is
+// handled. The default mode is [AlphaInclude].
+func WithAlphaMode(mode AlphaMode) NearestOption {
+	return func(o *nearestOptions) {
+		o.alpha = mode
+	}
+}
+
+// WithBackground is a color distance option to composite colors over the
+// background before measuring distance, ex: WithBackground(White). Sets the
+// alpha mode to [AlphaComposite]. The default background is white.
+func WithBackground(bg color.Color) NearestOption {
+	return func(o *nearestOptions) {
+		o.alpha, o.bg = AlphaComposite, toNRGBA(bg)
+		o.bg.A = 0xff
+	}
+}
+
+// nearestOptions are color distance options.
+type nearestOptions struct {
+	metric Metric
+	alpha  AlphaMode
+	bg     color.NRGBA
+}
+
+// newNearestOptions creates color distance options.
+func newNearestOptions(opts []NearestOption) nearestOptions {
+	o := nearestOptions{bg: color.NRGBA{0xff, 0xff, 0xff, 0xff}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.alpha != AlphaComposite {
+		o.bg = color.NRGBA{}
+	}
+	return o
+}
+
+// point returns the coordinates of the color for the options, where the
+// first 3 coordinates are in the metric's color space, and the last is the
+// scaled alpha.
+func (o nearestOptions) point(c color.NRGBA) [4]float64 {
+	v := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+	alpha := float64(c.A) / 0xff
+	switch o.alpha {
+	case AlphaIgnore:
+		alpha = 0
+	case AlphaComposite:
+		bg := [3]float64{float64(o.bg.R), float64(o.bg.G), float64(o.bg.B)}
+		for i := range v {
+			v[i] = v[i]*alpha + bg[i]*(1-alpha)
+		}
+		alpha = 0
+	}
+	var p [4]float64
+	switch o.metric {
+	case MetricRGB:
+		copy(p[:], v[:])
+	case MetricCIEDE2000:
+		c := FloatColor{SpaceSRGB, [3]float64{v[0] / 0xff, v[1] / 0xff, v[2] / 0xff}, 1}.Convert(SpaceLab)
+		copy(p[:], c.Channels[:])
+	default:
+		c := FloatColor{SpaceSRGB, [3]float64{v[0] / 0xff, v[1] / 0xff, v[2] / 0xff}, 1}.Convert(SpaceOKLab)
+		copy(p[:], c.Channels[:])
+	}
+	p[3] = alpha * o.metric.scale()
+	return p
+}
+
+// distance returns the distance between the points for the options.
+func (o nearestOptions) distance(a, b [4]float64) float64 {
+	if o.metric != MetricCIEDE2000 {
+		return math.Sqrt(dist2(a, b))
+	}
+	d := ciede2000(a[0], a[1], a[2], b[0], b[1], b[2])
+	return math.Sqrt(d*d + (a[3]-b[3])*(a[3]-b[3]))
+}
+
+// Distance returns the distance between two colors, using the options. See
+// [WithMetric] and [WithAlphaMode].
+func Distance(a, b color.Color, opts ...NearestOption) float64 {
+	o := newNearestOptions(opts)
+	x, y := o.point(toNRGBA(a)), o.point(toNRGBA(b))
+	return o.distance(x, y)
+}
+
+// Nearest returns the name of the color in the [DefaultRegistry] nearest to
+// c, and its distance, using the options. See [Registry.Nearest].
+func Nearest(c color.Color, opts ...NearestOption) (NamedColor, float64) {
+	return DefaultRegistry.Nearest(c, opts...)
+}
+
+// Nearest returns the name of the registered color nearest to c, and its
+// distance, using the options, ex: red for #fe0000. Returns the color's
+// canonical name when more than one name is registered for the color, and
+// an empty name and an infinite distance when the registry is empty.
+//
+// Lookups use a k-d tree of the registered colors, built for each metric
+// and alpha mode on first use, and rebuilt after the registry is changed.
+// With [AlphaComposite], the tree only has the opaque colors, which are the
+// same over any background, and translucent colors are composited over the
+// background when looking up the nearest color. With [MetricCIEDE2000], the
+// nearest colors by the CIE76 distance (the Euclidean distance in CIE Lab)
+// are found using the tree, and ranked by their CIEDE2000 distance.
+func (r *Registry) Nearest(c color.Color, opts ...NearestOption) (NamedColor, float64) {
+	o := newNearestOptions(opts)
+	t := r.tree(o)
+	if len(t.points) == 0 && len(t.translucent) == 0 {
+		return "", math.Inf(1)
+	}
+	q := o.point(toNRGBA(c))
+	k := 1
+	if o.metric == MetricCIEDE2000 {
+		k = ciede2000Candidates
+	}
+	var best NamedColor
+	d := math.Inf(1)
+	add := func(p [4]float64, name NamedColor) {
+		if v := o.distance(q, p); v < d || (v == d && name < best) {
+			best, d = name, v
+		}
+	}
+	for _, i := range t.nearest(q, k) {
+		add(t.points[i].p, t.points[i].name)
+	}
+	for _, p := range t.translucent {
+		add(o.point(p.c), p.name)
+	}
+	return best, d
+}
+
+// ciede2000Candidates is the number of candidates ranked by their CIEDE2000
+// distance.
+const ciede2000Candidates = 64
+
+// treeKey is the key of a k-d tree of the registered colors. The background
+// is not part of the key, as it is applied when looking up colors.
+type treeKey struct {
+	metric Metric
+	alpha  AlphaMode
+}
+
+// tree returns the k-d tree of the registered colors for the options,
+// building it when necessary.
+func (r *Registry) tree(o nearestOptions) *kdTree {
+	key := treeKey{o.metric, o.alpha}
+	r.mu.RLock()
+	t, ok := r.trees[key]
+	r.mu.RUnlock()
+	if ok {
+		return t
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t, ok := r.trees[key]; ok {
+		return t
+	}
+	points := make([]kdPoint, 0, len(r.lookup))
+	var translucent []kdColor
+	for k, n := range r.lookup {
+		c := color.NRGBA{uint8(k >> 24), uint8(k >> 16), uint8(k >> 8), uint8(k)}
+		if o.alpha == AlphaComposite && c.A != 0xff {
+			translucent = append(translucent, kdColor{c, n})
+			continue
+		}
+		points = append(points, kdPoint{o.point(c), n})
+	}
+	t = newKDTree(points, translucent)
+	if r.trees == nil {
+		r.trees = make(map[treeKey]*kdTree)
+	}
+	r.trees[key] = t
+	return t
+}
+
+// kdPoint is a k-d tree point.
+type kdPoint struct {
+	p    [4]float64
+	name NamedColor
+}
+
+// kdColor is a color that is not in a k-d tree, as its point depends on the
+// background.
+type kdColor struct {
+	c    color.NRGBA
+	name NamedColor
+}
+
+// kdTree is a k-d tree, stored as an implicit binary tree where the node for
+// points[lo:hi] is at the midpoint, and splits on axes[mid].
+type kdTree struct {
+	points []kdPoint
+	axes   []uint8
+	// translucent are the translucent colors with AlphaComposite, which are
+	// composited over the background when looking up colors.
+	translucent []kdColor
+}
+
+// newKDTree builds a k-d tree of the points.
+func newKDTree(points []kdPoint, translucent []kdColor) *kdTree {
+	// sort first, so the tree is the same for the same points
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].name < points[j].name
+	})
+	sort.Slice(translucent, func(i, j int) bool {
+		return translucent[i].name < translucent[j].name
+	})
+	t := &kdTree{points: points, axes: make([]uint8, len(points)), translucent: translucent}
+	t.build(0, len(points))
+	return t
+}
+
+// build builds the tree for points[lo:hi], splitting on the axis with the
+// largest spread.
+func (t *kdTree) build(lo, hi int) {
+	if hi-lo < 2 {
+		return
+	}
+	var axis uint8
+	spread := -1.0
+	for i := range 4 {
+		a, b := math.Inf(1), math.Inf(-1)
+		for _, p := range t.points[lo:hi] {
+			a, b = min(a, p.p[i]), max(b, p.p[i])
+		}
+		if b-a > spread {
+			axis, spread = uint8(i), b-a
+		}
+	}
+	v := t.points[lo:hi]
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].p[axis] < v[j].p[axis]
+	})
+	mid := (lo + hi) / 2
+	t.axes[mid] = axis
+	t.build(lo, mid)
+	t.build(mid+1, hi)
+}
+
+// nearest returns the indexes of the k nearest points to q, by Euclidean
+// distance.
+func (t *kdTree) nearest(q [4]float64, k int) []int {
+	s := kdSearch{t: t, q: q, k: k}
+	s.search(0, len(t.points))
+	return s.idx
+}
+
+// kdSearch is a k nearest neighbor search.
+type kdSearch struct {
+	t *kdTree
+	q [4]float64
+	k int
+	// idx and d are the indexes and squared distances of the nearest points,
+	// ordered by distance.
+	idx []int
+	d   []float64
+}
+
+// search searches points[lo:hi].
+func (s *kdSearch) search(lo, hi int) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	s.add(mid, dist2(s.q, s.t.points[mid].p))
+	axis := s.t.axes[mid]
+	diff := s.q[axis] - s.t.points[mid].p[axis]
+	if diff < 0 {
+		s.search(lo, mid)
+		if len(s.d) < s.k || diff*diff <= s.d[len(s.d)-1] {
+			s.search(mid+1, hi)
+		}
+	} else {
+		s.search(mid+1, hi)
+		if len(s.d) < s.k || diff*diff <= s.d[len(s.d)-1] {
+			s.search(lo, mid)
+		}
+	}
+}
+
+// add adds the point at index i with squared distance d, when it is one of
+// the k nearest. Points with the same distance are ordered by name.
+func (s *kdSearch) add(i int, d float64) {
+	less := func(j int) bool {
+		return d < s.d[j] || (d == s.d[j] && s.t.points[i].name < s.t.points[s.idx[j]].name)
+	}
+	if len(s.d) == s.k && !less(len(s.d)-1) {
+		return
+	}
+	j := sort.Search(len(s.d), less)
+	if len(s.d) < s.k {
+		s.idx, s.d = append(s.idx, 0), append(s.d, 0)
+	}
+	copy(s.idx[j+1:], s.idx[j:])
+	copy(s.d[j+1:], s.d[j:])
+	s.idx[j], s.d[j] = i, d
+}
+
+// toNRGBA converts the color to a [color.NRGBA], without the loss of
+// precision of converting colors with an alpha to premultiplied colors and
+// back.
+func toNRGBA(clr color.Color) color.NRGBA {
+	switch c := clr.(type) {
+	case Color:
+		return color.NRGBA{c.R, c.G, c.B, c.A}
+	case NamedColor:
+		return c.NRGBA()
+	}
+	return color.NRGBAModel.Convert(clr).(color.NRGBA)
+}
+
+// dist2 returns the squared Euclidean distance between a and b.
+func dist2(a, b [4]float64) float64 {
+	var d float64
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
+
+// ciede2000 returns the CIEDE2000 color difference between two CIE Lab
+// colors.
+//
+// See: Sharma, Wu, and Dalal, "The CIEDE2000 Color-Difference Formula:
+// Implementation Notes, Supplementary Test Data, and Mathematical
+// Observations" (2005).
+func ciede2000(l1, a1, b1, l2, a2, b2 float64) float64 {
+	const pow25_7 = 6103515625 // 25^7
+	rad := math.Pi / 180
+	cm := (math.Hypot(a1, b1) + math.Hypot(a2, b2)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cm, 7)/(math.Pow(cm, 7)+pow25_7)))
+	a1, a2 = a1*(1+g), a2*(1+g)
+	c1, c2 := math.Hypot(a1, b1), math.Hypot(a2, b2)
+	h1, h2 := hueAngle(a1, b1), hueAngle(a2, b2)
+	// differences
+	dl, dc, dh := l2-l1, c2-c1, 0.0
+	switch {
+	case c1*c2 == 0:
+	case math.Abs(h2-h1) <= 180:
+		dh = h2 - h1
+	case h2-h1 > 180:
+		dh = h2 - h1 - 360
+	default:
+		dh = h2 - h1 + 360
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(dh/2*rad)
+	// means
+	lm, cmp := (l1+l2)/2, (c1+c2)/2
+	var hm float64
+	switch {
+	case c1*c2 == 0:
+		hm = h1 + h2
+	case math.Abs(h1-h2) <= 180:
+		hm = (h1 + h2) / 2
+	case h1+h2 < 360:
+		hm = (h1 + h2 + 360) / 2
+	default:
+		hm = (h1 + h2 - 360) / 2
+	}
+	t := 1 - 0.17*math.Cos((hm-30)*rad) + 0.24*math.Cos(2*hm*rad) + 0.32*math.Cos((3*hm+6)*rad) - 0.20*math.Cos((4*hm-63)*rad)
+	theta := 30 * math.Exp(-((hm-275)/25)*((hm-275)/25))
+	rc := 2 * math.Sqrt(math.Pow(cmp, 7)/(math.Pow(cmp, 7)+pow25_7))
+	sl := 1 + 0.015*(lm-50)*(lm-50)/math.Sqrt(20+(lm-50)*(lm-50))
+	sc := 1 + 0.045*cmp
+	sh := 1 + 0.015*cmp*t
+	rt := -math.Sin(2*theta*rad) * rc
+	x, y, z := dl/sl, dc/sc, dH/sh
+	return math.Sqrt(x*x + y*y + z*z + rt*y*z)
+}
+
+// hueAngle returns the hue angle (0-360) of a and b.
+func hueAngle(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
@@ -0,0 +1,160 @@
+package colors
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestNearest(t *testing.T) {
+	tests := []struct {
+		c    color.Color
+		opts []NearestOption
+		exp  NamedColor
+	}{
+		{color.NRGBA{0xfe, 0, 0, 0xff}, nil, Red},
+		{color.NRGBA{0xfe, 0, 0, 0xff}, []NearestOption{WithMetric(MetricRGB)}, Red},
+		{color.NRGBA{0xfe, 0, 0, 0xff}, []NearestOption{WithMetric(MetricCIEDE2000)}, Red},
+		{color.NRGBA{0x80, 0x80, 0x80, 0xff}, nil, Gray},
+		{color.NRGBA{0, 0xff, 0xff, 0xff}, nil, Aqua},
+		{color.NRGBA{0xff, 0xe4, 0xe0, 0xff}, nil, Mistyrose},
+		{color.NRGBA{0x64, 0x95, 0xec, 0xff}, nil, Cornflowerblue},
+		{color.NRGBA{0, 0, 0, 0}, nil, Transparent},
+		{color.NRGBA{0, 0, 0, 0x10}, nil, Transparent},
+		{color.NRGBA{0, 0, 0, 0x10}, []NearestOption{WithAlphaMode(AlphaIgnore)}, Black},
+		{color.NRGBA{0xff, 0, 0, 0x80}, []NearestOption{WithAlphaMode(AlphaIgnore)}, Red},
+		{color.NRGBA{0, 0, 0, 0x10}, []NearestOption{WithAlphaMode(AlphaComposite)}, Whitesmoke},
+		{color.NRGBA{0, 0, 0, 0x10}, []NearestOption{WithBackground(Black)}, Black},
+		{color.NRGBA{0, 0, 0xff, 0x80}, []NearestOption{WithBackground(White)}, Mediumslateblue},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v", test.c), func(t *testing.T) {
+			n, d := Nearest(test.c, test.opts...)
+			if n != test.exp {
+				t.Errorf("expected %s, got: %s (%f)", test.exp, n, d)
+			}
+			if exp := Distance(test.c, test.exp, test.opts...); d != exp {
+				t.Errorf("expected distance %f, got: %f", exp, d)
+			}
+		})
+	}
+	if n, d := Nearest(Red); n != Red || d != 0 {
+		t.Errorf("expected red 0, got: %s %f", n, d)
+	}
+}
+
+func TestNearestTree(t *testing.T) {
+	// a registry of a similar size to the x11 color names
+	r := NewRegistry()
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for i := range 1000 {
+		r.RegisterName(fmt.Sprintf("c%d", i), color.NRGBA{uint8(rnd.UintN(256)), uint8(rnd.UintN(256)), uint8(rnd.UintN(256)), uint8(rnd.UintN(256))})
+	}
+	var opts [][]NearestOption
+	for _, m := range []Metric{MetricOKLab, MetricRGB, MetricCIEDE2000} {
+		for _, mode := range []AlphaMode{AlphaInclude, AlphaIgnore, AlphaComposite} {
+			opts = append(opts, []NearestOption{WithMetric(m), WithAlphaMode(mode)})
+		}
+	}
+	names := r.Map()
+	for _, o := range opts {
+		t.Run(fmt.Sprintf("%s/%s", newNearestOptions(o).metric, newNearestOptions(o).alpha), func(t *testing.T) {
+			var misses int
+			for range 500 {
+				c := color.NRGBA{uint8(rnd.UintN(256)), uint8(rnd.UintN(256)), uint8(rnd.UintN(256)), uint8(rnd.UintN(256))}
+				// brute force
+				exp := math.Inf(1)
+				for _, v := range names {
+					exp = min(exp, Distance(c, v, o...))
+				}
+				n, d := r.Nearest(c, o...)
+				if v := Distance(c, names[n], o...); v != d {
+					t.Fatalf("expected %s distance %f, got: %f", n, v, d)
+				}
+				if d != exp {
+					misses++
+				}
+			}
+			// the ciede2000 candidates are found using the cie76 distance
+			if misses != 0 && (newNearestOptions(o).metric != MetricCIEDE2000 || misses > 5) {
+				t.Errorf("expected nearest colors, got %d misses", misses)
+			}
+		})
+	}
+	// empty
+	r = NewRegistry()
+	for _, n := range r.Names() {
+		r.Unregister(string(n))
+	}
+	if n, d := r.Nearest(Red); n != "" || !math.IsInf(d, 1) {
+		t.Errorf("expected no name, got: %q %f", n, d)
+	}
+	r.RegisterName("brand", Red)
+	if n, _ := r.Nearest(Blue); n != "brand" {
+		t.Errorf("expected brand, got: %q", n)
+	}
+}
+
+func TestNearestBackground(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterName("brand", color.NRGBA{0xff, 0, 0, 0x80})
+	for i := range 256 {
+		bg := color.NRGBA{uint8(i), uint8(255 - i), 0x80, 0xff}
+		if n, d := r.Nearest(color.NRGBA{0xff, 0, 0, 0x80}, WithBackground(bg)); n != "brand" || d != 0 {
+			t.Fatalf("%v: expected brand 0, got: %s %f", bg, n, d)
+		}
+		if n, d := r.Nearest(Red, WithBackground(bg)); n != Red || d != 0 {
+			t.Fatalf("%v: expected red 0, got: %s %f", bg, n, d)
+		}
+	}
+	// backgrounds share a tree
+	if n := len(r.trees); n != 1 {
+		t.Errorf("expected 1 tree, got: %d", n)
+	}
+}
+
+func TestCIEDE2000(t *testing.T) {
+	// Sharma, Wu, and Dalal test data
+	tests := []struct {
+		a, b [3]float64
+		exp  float64
+	}{
+		{[3]float64{50, 2.6772, -79.7751}, [3]float64{50, 0, -82.7485}, 2.0425},
+		{[3]float64{50, 3.1571, -77.2803}, [3]float64{50, 0, -82.7485}, 2.8615},
+		{[3]float64{50, -1.3802, -84.2814}, [3]float64{50, 0, -82.7485}, 1.0000},
+		{[3]float64{50, 0, 0}, [3]float64{50, -1, 2}, 2.3669},
+		{[3]float64{50, 2.49, -0.001}, [3]float64{50, -2.49, 0.0009}, 7.1792},
+		{[3]float64{50, 2.5, 0}, [3]float64{73, 25, -18}, 27.1492},
+		{[3]float64{50, 2.5, 0}, [3]float64{56, -27, -3}, 31.9030},
+		{[3]float64{60.2574, -34.0099, 36.2677}, [3]float64{60.4626, -34.1751, 39.4387}, 1.2644},
+		{[3]float64{22.7233, 20.0904, -46.6940}, [3]float64{23.0331, 14.9730, -42.5619}, 2.0373},
+		{[3]float64{90.8027, -2.0831, 1.4410}, [3]float64{91.1528, -1.6435, 0.0447}, 1.4441},
+		{[3]float64{90.9257, -0.5406, -0.9208}, [3]float64{88.6381, -0.8985, -0.7239}, 1.5381},
+		{[3]float64{2.0776, 0.0795, -1.1350}, [3]float64{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+	for _, test := range tests {
+		for _, v := range [][2][3]float64{{test.a, test.b}, {test.b, test.a}} {
+			if d := ciede2000(v[0][0], v[0][1], v[0][2], v[1][0], v[1][1], v[1][2]); math.Abs(d-test.exp) > 0.0001 {
+				t.Errorf("%v %v: expected %f, got: %f", v[0], v[1], test.exp, d)
+			}
+		}
+	}
+}
+
+func BenchmarkNearest(b *testing.B) {
+	r := NewRegistry()
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for i := range 1000 {
+		r.RegisterName(fmt.Sprintf("c%d", i), color.NRGBA{uint8(rnd.UintN(256)), uint8(rnd.UintN(256)), uint8(rnd.UintN(256)), 0xff})
+	}
+	c := color.NRGBA{0xfe, 0, 0, 0xff}
+	for _, m := range []Metric{MetricOKLab, MetricRGB, MetricCIEDE2000} {
+		b.Run(m.String(), func(b *testing.B) {
+			for range b.N {
+				_, _ = r.Nearest(c, WithMetric(m))
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+)
+
+// OKLab is a color in the OKLab color space, as defined in the CSS Color
+// Level 4 spec.
+//
+// L is the perceived lightness (0-1), A and B are the green-red and
+// blue-yellow axes, and Alpha is the alpha (0-1).
+type OKLab struct {
+	L, A, B, Alpha float64
+}
+
+// OKLCH is a color in the OKLCH color space, the polar form of [OKLab].
+//
+// L is the perceived lightness (0-1), C is the chroma, H is the hue in
+// degrees, and Alpha is the alpha (0-1).
+type OKLCH struct {
+	L, C, H, Alpha float64
+}
+
+// FromOKLab converts a oklab string to a color, ex: oklab(0.628 0.2249
+// 0.1258) or oklab(62.8% 56.22% 31.46% / 0.5).
+//
+// Lightness may be a number (0-1) or a percentage, and the a and b axes may
+// be numbers or percentages, where 100% is 0.4. Colors outside of the sRGB
+// gamut are gamut mapped. See [FloatColor.Color].
+func FromOKLab(s string) (Color, bool) {
+	c, err := options{}.parseOKLab(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// FromOKLCH converts a oklch string to a color, ex: oklch(0.628 0.2577
+// 29.23) or oklch(62.8% 64.42% 29.23deg / 0.5).
+//
+// Lightness may be a number (0-1) or a percentage, chroma may be a number or
+// a percentage, where 100% is 0.4, and the hue may be specified with any of
+// the units supported by [FromHSL]. Colors outside of the sRGB gamut are
+// gamut mapped. See [FloatColor.Color].
+func FromOKLCH(s string) (Color, bool) {
+	c, err := options{}.parseOKLCH(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// OKLab returns the color as a [OKLab].
+func (c Color) OKLab() OKLab {
+	v := xyzToOKLab(srgbToXYZ65(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff))
+	return OKLab{v[0], v[1], v[2], float64(c.A) / 0xff}
+}
+
+// OKLCH returns the color as a [OKLCH].
+func (c Color) OKLCH() OKLCH {
+	return c.OKLab().OKLCH()
+}
+
+// AsOKLCH returns the color formatted as a oklch string, ex:
+// oklch(62.796% 0.25768 29.234) or oklch(62.796% 0.25768 29.234 / 0.5).
+func (c Color) AsOKLCH() string {
+	v := c.OKLCH()
+	l, ch, h := formatFloat(v.L*100, 3), formatFloat(v.C, 5), formatFloat(v.H, 3)
+	if ch == "0" {
+		h = "0"
+	}
+	if c.A != 0xff {
+		return fmt.Sprintf("oklch(%s%% %s %s / %s)", l, ch, h, formatFloat(v.Alpha, 3))
+	}
+	return fmt.Sprintf("oklch(%s%% %s %s)", l, ch, h)
+}
+
+// OKLCH converts the OKLab to a [OKLCH].
+func (v OKLab) OKLCH() OKLCH {
+	l, c, h := toPolar(v.L, v.A, v.B)
+	return OKLCH{l, c, h, v.Alpha}
+}
+
+// Color converts the OKLab to a [Color], and reports whether the color was
+// within the sRGB gamut. Out of gamut channels are clipped.
+func (v OKLab) Color() (Color, bool) {
+	r, g, b := xyz65ToSRGB(oklabToXYZ([3]float64{v.L, v.A, v.B}))
+	return New(toUint8(r), toUint8(g), toUint8(b), toUint8(v.Alpha)), inGamut(r, g, b)
+}
+
+// RGBA satisfies the [color.Color] interface.
+func (v OKLab) RGBA() (r, g, b, a uint32) {
+	c, _ := v.Color()
+	return c.RGBA()
+}
+
+// OKLab converts the OKLCH to a [OKLab].
+func (v OKLCH) OKLab() OKLab {
+	l, a, b := fromPolar(v.L, v.C, v.H)
+	return OKLab{l, a, b, v.Alpha}
+}
+
+// Color converts the OKLCH to a [Color], and reports whether the color was
+// within the sRGB gamut. Out of gamut channels are clipped.
+func (v OKLCH) Color() (Color, bool) {
+	return v.OKLab().Color()
+}
+
+// RGBA satisfies the [color.Color] interface.
+func (v OKLCH) RGBA() (r, g, b, a uint32) {
+	return v.OKLab().RGBA()
+}
+
+// parseOKLab parses a oklab string.
+func (o options) parseOKLab(s string) (FloatColor, *ParseError) {
+	return o.parseLabFunc(s, "oklab", SpaceOKLab, [3]channel{{1, 0, 1}, {0.4, math.Inf(-1), math.Inf(1)}, {0.4, math.Inf(-1), math.Inf(1)}})
+}
+
+// parseOKLCH parses a oklch string.
+func (o options) parseOKLCH(s string) (FloatColor, *ParseError) {
+	return o.parseLabFunc(s, "oklch", SpaceOKLCH, [3]channel{{1, 0, 1}, {0.4, 0, math.Inf(1)}, hue})
+}
+
+// xyzToOKLab converts CIE XYZ (D65) to OKLab.
+//
+// See: https://www.w3.org/TR/css-color-4/#color-conversion-code
+func xyzToOKLab(v [3]float64) [3]float64 {
+	lms := xyzToLMS.mul(v)
+	return lmsToOKLab.mul([3]float64{math.Cbrt(lms[0]), math.Cbrt(lms[1]), math.Cbrt(lms[2])})
+}
+
+// oklabToXYZ converts OKLab to CIE XYZ (D65).
+//
+// See: https://www.w3.org/TR/css-color-4/#color-conversion-code
+func oklabToXYZ(v [3]float64) [3]float64 {
+	lms := oklabToLMS.mul(v)
+	return lmsToXYZ.mul([3]float64{lms[0] * lms[0] * lms[0], lms[1] * lms[1] * lms[1], lms[2] * lms[2] * lms[2]})
+}
+
+// OKLab conversion matrices.
+//
+// See: https://www.w3.org/TR/css-color-4/#color-conversion-code
+var (
+	xyzToLMS = matrix{
+		{0.8190224379967030, 0.3619062600528904, -0.1288737815209879},
+		{0.0329836539323885, 0.9292868615863434, 0.0361446663506424},
+		{0.0481771893596242, 0.2642395317527308, 0.6335478284694309},
+	}
+	lmsToOKLab = matrix{
+		{0.2104542683093140, 0.7936177747023054, -0.0040720430116193},
+		{1.9779985324311684, -2.4285922420485799, 0.4505937096174110},
+		{0.0259040424655478, 0.7827717124575296, -0.8086757549230774},
+	}
+	lmsToXYZ = matrix{
+		{1.2268798758459243, -0.5578149944602171, 0.2813910456659647},
+		{-0.0405757452148008, 1.1122868032803170, -0.0717110580655164},
+		{-0.0763729366746601, -0.4214933324022432, 1.5869240198367816},
+	}
+	oklabToLMS = matrix{
+		{1.0000000000000000, 0.3963377773761749, 0.2158037573299490},
+		{1.0000000000000000, -0.1055613458156586, -0.0638541728258133},
+		{1.0000000000000000, -0.0894841775298119, -1.2914855480194092},
+	}
+)
@@ -0,0 +1,34 @@
+package palette
+
+// Apple are the Apple system colors (light appearance), with names such as
+// blue and gray2, in the namespace apple.
+//
+// See: https://developer.apple.com/design/human-interface-guidelines/color
+var Apple = New("apple", "Apple System Colors", "iOS 15", build(appleNames, appleLight))
+
+// AppleDark are the Apple system colors (dark appearance), in the namespace
+// apple-dark. See [Apple].
+var AppleDark = New("apple-dark", "Apple System Colors (Dark)", "iOS 15", build(appleNames, appleDark))
+
+// appleNames are the Apple system color names.
+var appleNames = []string{
+	"red", "orange", "yellow", "green", "mint", "teal", "cyan", "blue",
+	"indigo", "purple", "pink", "brown",
+	"gray", "gray2", "gray3", "gray4", "gray5", "gray6",
+}
+
+// appleLight are the Apple system colors (light appearance), in the order of
+// appleNames.
+var appleLight = []uint32{
+	0xff3b30, 0xff9500, 0xffcc00, 0x34c759, 0x00c7be, 0x30b0c7, 0x32ade6, 0x007aff,
+	0x5856d6, 0xaf52de, 0xff2d55, 0xa2845e,
+	0x8e8e93, 0xaeaeb2, 0xc7c7cc, 0xd1d1d6, 0xe5e5ea, 0xf2f2f7,
+}
+
+// appleDark are the Apple system colors (dark appearance), in the order of
+// appleNames.
+var appleDark = []uint32{
+	0xff453a, 0xff9f0a, 0xffd60a, 0x30d158, 0x63e6e2, 0x40c8e0, 0x64d2ff, 0x0a84ff,
+	0x5e5ce6, 0xbf5af2, 0xff375f, 0xac8e68,
+	0x8e8e93, 0x636366, 0x48484a, 0x3a3a3c, 0x2c2c2e, 0x1c1c1e,
+}
@@ -0,0 +1,100 @@
+package palette
+
+// Material2 is the Material Design 2 color palette, with names such as red-500
+// and deep-purple-A200, in the namespace material.
+//
+// See: https://m2.material.io/design/color/the-color-system.html
+var Material2 = New("material", "Material Design 2", "2", material2())
+
+// Material3 is the Material Design 3 baseline light color scheme, with names
+// such as primary and on-primary-container, in the namespace material3.
+//
+// See: https://m3.material.io/styles/color/static/baseline
+var Material3 = New("material3", "Material Design 3", "3", build(material3Roles, material3Light))
+
+// Material3Dark is the Material Design 3 baseline dark color scheme, in the
+// namespace material3-dark. See [Material3].
+var Material3Dark = New("material3-dark", "Material Design 3 (Dark)", "3", build(material3Roles, material3Dark))
+
+// material2 returns the Material Design 2 entries, ordered by hue, followed
+// by black and white.
+func material2() []Entry {
+	var v []Entry
+	for _, h := range material2Hues {
+		for i, c := range h.shades {
+			v = append(v, Entry{h.name + "-" + material2Shades[i], hex(c)})
+		}
+		for i, c := range h.accents {
+			v = append(v, Entry{h.name + "-" + material2Accents[i], hex(c)})
+		}
+	}
+	return append(v, Entry{"black", hex(0x000000)}, Entry{"white", hex(0xffffff)})
+}
+
+// material2Shades are the Material Design 2 shades.
+var material2Shades = [10]string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900"}
+
+// material2Accents are the Material Design 2 accent shades.
+var material2Accents = [4]string{"A100", "A200", "A400", "A700"}
+
+// material2Hues are the Material Design 2 hues.
+var material2Hues = []struct {
+	name    string
+	shades  [10]uint32
+	accents []uint32
+}{
+	{"red", [10]uint32{0xffebee, 0xffcdd2, 0xef9a9a, 0xe57373, 0xef5350, 0xf44336, 0xe53935, 0xd32f2f, 0xc62828, 0xb71c1c}, []uint32{0xff8a80, 0xff5252, 0xff1744, 0xd50000}},
+	{"pink", [10]uint32{0xfce4ec, 0xf8bbd0, 0xf48fb1, 0xf06292, 0xec407a, 0xe91e63, 0xd81b60, 0xc2185b, 0xad1457, 0x880e4f}, []uint32{0xff80ab, 0xff4081, 0xf50057, 0xc51162}},
+	{"purple", [10]uint32{0xf3e5f5, 0xe1bee7, 0xce93d8, 0xba68c8, 0xab47bc, 0x9c27b0, 0x8e24aa, 0x7b1fa2, 0x6a1b9a, 0x4a148c}, []uint32{0xea80fc, 0xe040fb, 0xd500f9, 0xaa00ff}},
+	{"deep-purple", [10]uint32{0xede7f6, 0xd1c4e9, 0xb39ddb, 0x9575cd, 0x7e57c2, 0x673ab7, 0x5e35b1, 0x512da8, 0x4527a0, 0x311b92}, []uint32{0xb388ff, 0x7c4dff, 0x651fff, 0x6200ea}},
+	{"indigo", [10]uint32{0xe8eaf6, 0xc5cae9, 0x9fa8da, 0x7986cb, 0x5c6bc0, 0x3f51b5, 0x3949ab, 0x303f9f, 0x283593, 0x1a237e}, []uint32{0x8c9eff, 0x536dfe, 0x3d5afe, 0x304ffe}},
+	{"blue", [10]uint32{0xe3f2fd, 0xbbdefb, 0x90caf9, 0x64b5f6, 0x42a5f5, 0x2196f3, 0x1e88e5, 0x1976d2, 0x1565c0, 0x0d47a1}, []uint32{0x82b1ff, 0x448aff, 0x2979ff, 0x2962ff}},
+	{"light-blue", [10]uint32{0xe1f5fe, 0xb3e5fc, 0x81d4fa, 0x4fc3f7, 0x29b6f6, 0x03a9f4, 0x039be5, 0x0288d1, 0x0277bd, 0x01579b}, []uint32{0x80d8ff, 0x40c4ff, 0x00b0ff, 0x0091ea}},
+	{"cyan", [10]uint32{0xe0f7fa, 0xb2ebf2, 0x80deea, 0x4dd0e1, 0x26c6da, 0x00bcd4, 0x00acc1, 0x0097a7, 0x00838f, 0x006064}, []uint32{0x84ffff, 0x18ffff, 0x00e5ff, 0x00b8d4}},
+	{"teal", [10]uint32{0xe0f2f1, 0xb2dfdb, 0x80cbc4, 0x4db6ac, 0x26a69a, 0x009688, 0x00897b, 0x00796b, 0x00695c, 0x004d40}, []uint32{0xa7ffeb, 0x64ffda, 0x1de9b6, 0x00bfa5}},
+	{"green", [10]uint32{0xe8f5e9, 0xc8e6c9, 0xa5d6a7, 0x81c784, 0x66bb6a, 0x4caf50, 0x43a047, 0x388e3c, 0x2e7d32, 0x1b5e20}, []uint32{0xb9f6ca, 0x69f0ae, 0x00e676, 0x00c853}},
+	{"light-green", [10]uint32{0xf1f8e9, 0xdcedc8, 0xc5e1a5, 0xaed581, 0x9ccc65, 0x8bc34a, 0x7cb342, 0x689f38, 0x558b2f, 0x33691e}, []uint32{0xccff90, 0xb2ff59, 0x76ff03, 0x64dd17}},
+	{"lime", [10]uint32{0xf9fbe7, 0xf0f4c3, 0xe6ee9c, 0xdce775, 0xd4e157, 0xcddc39, 0xc0ca33, 0xafb42b, 0x9e9d24, 0x827717}, []uint32{0xf4ff81, 0xeeff41, 0xc6ff00, 0xaeea00}},
+	{"yellow", [10]uint32{0xfffde7, 0xfff9c4, 0xfff59d, 0xfff176, 0xffee58, 0xffeb3b, 0xfdd835, 0xfbc02d, 0xf9a825, 0xf57f17}, []uint32{0xffff8d, 0xffff00, 0xffea00, 0xffd600}},
+	{"amber", [10]uint32{0xfff8e1, 0xffecb3, 0xffe082, 0xffd54f, 0xffca28, 0xffc107, 0xffb300, 0xffa000, 0xff8f00, 0xff6f00}, []uint32{0xffe57f, 0xffd740, 0xffc400, 0xffab00}},
+	{"orange", [10]uint32{0xfff3e0, 0xffe0b2, 0xffcc80, 0xffb74d, 0xffa726, 0xff9800, 0xfb8c00, 0xf57c00, 0xef6c00, 0xe65100}, []uint32{0xffd180, 0xffab40, 0xff9100, 0xff6d00}},
+	{"deep-orange", [10]uint32{0xfbe9e7, 0xffccbc, 0xffab91, 0xff8a65, 0xff7043, 0xff5722, 0xf4511e, 0xe64a19, 0xd84315, 0xbf360c}, []uint32{0xff9e80, 0xff6e40, 0xff3d00, 0xdd2c00}},
+	{"brown", [10]uint32{0xefebe9, 0xd7ccc8, 0xbcaaa4, 0xa1887f, 0x8d6e63, 0x795548, 0x6d4c41, 0x5d4037, 0x4e342e, 0x3e2723}, nil},
+	{"grey", [10]uint32{0xfafafa, 0xf5f5f5, 0xeeeeee, 0xe0e0e0, 0xbdbdbd, 0x9e9e9e, 0x757575, 0x616161, 0x424242, 0x212121}, nil},
+	{"blue-grey", [10]uint32{0xeceff1, 0xcfd8dc, 0xb0bec5, 0x90a4ae, 0x78909c, 0x607d8b, 0x546e7a, 0x455a64, 0x37474f, 0x263238}, nil},
+}
+
+// material3Roles are the Material Design 3 color roles.
+var material3Roles = []string{
+	"primary", "on-primary", "primary-container", "on-primary-container",
+	"secondary", "on-secondary", "secondary-container", "on-secondary-container",
+	"tertiary", "on-tertiary", "tertiary-container", "on-tertiary-container",
+	"error", "on-error", "error-container", "on-error-container",
+	"background", "on-background", "surface", "on-surface",
+	"surface-variant", "on-surface-variant", "outline", "outline-variant",
+	"shadow", "scrim", "inverse-surface", "inverse-on-surface", "inverse-primary",
+}
+
+// material3Light are the Material Design 3 baseline light scheme colors, in
+// the order of material3Roles.
+var material3Light = []uint32{
+	0x6750a4, 0xffffff, 0xeaddff, 0x21005d,
+	0x625b71, 0xffffff, 0xe8def8, 0x1d192b,
+	0x7d5260, 0xffffff, 0xffd8e4, 0x31111d,
+	0xb3261e, 0xffffff, 0xf9dedc, 0x410e0b,
+	0xfffbfe, 0x1c1b1f, 0xfffbfe, 0x1c1b1f,
+	0xe7e0ec, 0x49454f, 0x79747e, 0xcac4d0,
+	0x000000, 0x000000, 0x313033, 0xf4eff4, 0xd0bcff,
+}
+
+// material3Dark are the Material Design 3 baseline dark scheme colors, in
+// the order of material3Roles.
+var material3Dark = []uint32{
+	0xd0bcff, 0x381e72, 0x4f378b, 0xeaddff,
+	0xccc2dc, 0x332d41, 0x4a4458, 0xe8def8,
+	0xefb8c8, 0x492532, 0x633b48, 0xffd8e4,
+	0xf2b8b5, 0x601410, 0x8c1d18, 0xf9dedc,
+	0x1c1b1f, 0xe6e1e5, 0x1c1b1f, 0xe6e1e5,
+	0x49454f, 0xcac4d0, 0x938f99, 0x49454f,
+	0x000000, 0x000000, 0xe6e1e5, 0x313033, 0x6750a4,
+}
@@ -0,0 +1,194 @@
+// Package palette provides well-known design system color palettes, for use
+// with the colors package: Material Design 2 and 3, Apple system colors, and
+// Open Color.
+//
+// Each palette has a namespace, used to refer to the palette's colors with a
+// namespace prefix, ex: material:red-500 or open-color:blue-5. The palettes
+// are not enabled by default. Use [Register] to parse prefixed names with a
+// [colors.Parser], or [RegisterNames] to register the prefixed names for use
+// with [colors.FromName].
+package palette
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/kenshaw/colors"
+)
+
+// Entry is a palette entry.
+type Entry struct {
+	Name  string
+	Color colors.Color
+}
+
+// Palette is a named, versioned color palette, with an ordered list of
+// entries.
+type Palette struct {
+	namespace string
+	name      string
+	version   string
+	entries   []Entry
+	// index are the entry indexes, keyed by their normalized name.
+	index map[string]int
+}
+
+// New creates a new palette with the namespace (ex: material), display name
+// (ex: Material Design), version, and entries, in order.
+//
+// Entry names are case-insensitive, and ignore whitespace and punctuation,
+// such that deep-purple-A200, Deep_Purple_A200, and deeppurplea200 are
+// equivalent. When more than one entry has the same name, the first is
+// used.
+func New(namespace, name, version string, entries []Entry) *Palette {
+	p := &Palette{
+		namespace: namespace,
+		name:      name,
+		version:   version,
+		entries:   append([]Entry(nil), entries...),
+		index:     make(map[string]int, len(entries)),
+	}
+	for i, e := range p.entries {
+		if k := normalize(e.Name); k != "" {
+			if _, ok := p.index[k]; !ok {
+				p.index[k] = i
+			}
+		}
+	}
+	return p
+}
+
+// Namespace returns the palette's namespace, ex: material.
+func (p *Palette) Namespace() string {
+	return p.namespace
+}
+
+// Name returns the palette's display name, ex: Material Design 2.
+func (p *Palette) Name() string {
+	return p.name
+}
+
+// Version returns the palette's version, ex: 2.
+func (p *Palette) Version() string {
+	return p.version
+}
+
+// Entries returns a copy of the palette's entries, in order, ex: for
+// rendering swatches.
+func (p *Palette) Entries() []Entry {
+	return append([]Entry(nil), p.entries...)
+}
+
+// Palette returns the palette's colors, in order, as a [color.Palette].
+func (p *Palette) Palette() color.Palette {
+	v := make(color.Palette, len(p.entries))
+	for i, e := range p.entries {
+		v[i] = e.Color
+	}
+	return v
+}
+
+// Lookup returns the palette's named color, ex: red-500. The name may have
+// the palette's namespace prefix, ex: material:red-500.
+func (p *Palette) Lookup(name string) (colors.Color, bool) {
+	name = strings.TrimSpace(name)
+	if ns, s, ok := strings.Cut(name, ":"); ok {
+		if !strings.EqualFold(strings.TrimSpace(ns), p.namespace) {
+			return colors.Color{}, false
+		}
+		name = s
+	}
+	i, ok := p.index[normalize(name)]
+	if !ok {
+		return colors.Color{}, false
+	}
+	return p.entries[i].Color, true
+}
+
+// Format returns a parse format for the palette, for use with
+// [colors.Parser.RegisterFormat], that parses the palette's names with its
+// namespace prefix, ex: material:red-500.
+func (p *Palette) Format() func(string) (colors.Color, bool) {
+	return func(s string) (colors.Color, bool) {
+		if ns, _, ok := strings.Cut(s, ":"); !ok || !strings.EqualFold(strings.TrimSpace(ns), p.namespace) {
+			return colors.Color{}, false
+		}
+		return p.Lookup(s)
+	}
+}
+
+// Palettes returns the built-in palettes.
+func Palettes() []*Palette {
+	return []*Palette{Material2, Material3, Material3Dark, Apple, AppleDark, OpenColor}
+}
+
+// Lookup returns the named color from the built-in palettes, where the name
+// has a namespace prefix, ex: material:red-500 or apple:blue.
+func Lookup(name string) (colors.Color, bool) {
+	ns, _, ok := strings.Cut(name, ":")
+	if !ok {
+		return colors.Color{}, false
+	}
+	ns = strings.TrimSpace(ns)
+	for _, p := range Palettes() {
+		if strings.EqualFold(ns, p.namespace) {
+			return p.Lookup(name)
+		}
+	}
+	return colors.Color{}, false
+}
+
+// Register registers the palettes with the parser, as formats named by each
+// palette's namespace, ex:
+//
+//	p := colors.NewParser()
+//	palette.Register(p, palette.Material2, palette.OpenColor)
+//	c, err := p.Parse("material:deep-purple-A200")
+//
+// The formats are tried after the built-in formats.
+func Register(p *colors.Parser, palettes ...*Palette) {
+	for _, pal := range palettes {
+		p.RegisterFormat(pal.namespace, pal.Format())
+	}
+}
+
+// RegisterNames registers the names of the palettes' entries with
+// [colors.RegisterName], with each palette's namespace prefix, ex:
+// material:red-500, for use with [colors.FromName] and [colors.Parse].
+//
+// As with [colors.RegisterName], the names are found using their
+// normalized form, such that material:red-500 is also found with
+// material-red-500 or MaterialRed500.
+func RegisterNames(palettes ...*Palette) {
+	for _, p := range palettes {
+		for _, e := range p.entries {
+			colors.RegisterName(p.namespace+":"+e.Name, e.Color)
+		}
+	}
+}
+
+// normalize normalizes a name, lower casing it and removing all characters
+// other than letters and digits.
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// hex returns the color for the 0xrrggbb value.
+func hex(v uint32) colors.Color {
+	return colors.FromUint32(v<<8|0xff, colors.OrderRGBA)
+}
+
+// build builds a palette's entries from names and 0xrrggbb values.
+func build(names []string, values []uint32) []Entry {
+	v := make([]Entry, len(names))
+	for i, name := range names {
+		v[i] = Entry{name, hex(values[i])}
+	}
+	return v
+}
@@ -0,0 +1,119 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/kenshaw/colors"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"material:red-500", "#f44336"},
+		{"material:deep-purple-A200", "#7c4dff"},
+		{"Material:Deep_Purple_A200", "#7c4dff"},
+		{"material: blue-grey-900", "#263238"},
+		{"material:white", "#ffffff"},
+		{"material3:primary", "#6750a4"},
+		{"material3:on-primary-container", "#21005d"},
+		{"material3-dark:primary", "#d0bcff"},
+		{"apple:blue", "#007aff"},
+		{"apple-dark:blue", "#0a84ff"},
+		{"apple:gray6", "#f2f2f7"},
+		{"open-color:blue-5", "#339af0"},
+		{"open-color:gray-9", "#212529"},
+		{"material:brown-A200", ""},
+		{"material:red-550", ""},
+		{"red-500", ""},
+		{"unknown:red-500", ""},
+		{"open-color:", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, ok := Lookup(test.s)
+			switch {
+			case test.exp == "" && ok:
+				t.Fatalf("expected not ok, got: %s", c.AsWeb())
+			case test.exp == "":
+			case !ok:
+				t.Fatalf("expected ok")
+			case c.AsWeb() != test.exp:
+				t.Errorf("expected %s, got: %s", test.exp, c.AsWeb())
+			}
+		})
+	}
+}
+
+func TestPalettes(t *testing.T) {
+	tests := []struct {
+		p     *Palette
+		n     int
+		first string
+		last  string
+	}{
+		{Material2, 19*10 + 16*4 + 2, "red-50", "white"},
+		{Material3, 29, "primary", "inverse-primary"},
+		{Material3Dark, 29, "primary", "inverse-primary"},
+		{Apple, 18, "red", "gray6"},
+		{AppleDark, 18, "red", "gray6"},
+		{OpenColor, 2 + 13*10, "white", "orange-9"},
+	}
+	for _, test := range tests {
+		t.Run(test.p.Namespace(), func(t *testing.T) {
+			entries, pal := test.p.Entries(), test.p.Palette()
+			if len(entries) != test.n {
+				t.Fatalf("expected %d entries, got: %d", test.n, len(entries))
+			}
+			if len(pal) != len(entries) {
+				t.Fatalf("expected %d colors, got: %d", len(entries), len(pal))
+			}
+			if v := entries[0].Name; v != test.first {
+				t.Errorf("expected first %s, got: %s", test.first, v)
+			}
+			if v := entries[len(entries)-1].Name; v != test.last {
+				t.Errorf("expected last %s, got: %s", test.last, v)
+			}
+			for i, e := range entries {
+				if pal[i] != e.Color {
+					t.Errorf("expected %d to be %v, got: %v", i, e.Color, pal[i])
+				}
+				if c, ok := test.p.Lookup(e.Name); !ok || c != e.Color {
+					t.Errorf("expected %s to be %s", e.Name, e.Color.AsWeb())
+				}
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	p := colors.NewParser()
+	Register(p, Material2, OpenColor)
+	for _, s := range []string{"material:red-500", "open-color:blue-5", "rgb(from material:red-500 r g b / 50%)"} {
+		if _, err := p.Parse(s); err != nil {
+			t.Errorf("%q: expected no error, got: %v", s, err)
+		}
+	}
+	if _, err := p.Parse("apple:blue"); err == nil {
+		t.Errorf("expected error")
+	}
+	if _, err := colors.Parse("material:red-500"); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestRegisterNames(t *testing.T) {
+	RegisterNames(Material2, Apple)
+	for _, s := range []string{"material:red-500", "Material-Red-500", "apple:blue"} {
+		if _, ok := colors.FromName(s); !ok {
+			t.Errorf("%q: expected ok", s)
+		}
+	}
+	if c, _ := colors.Parse("material:deep-purple-A200"); c.AsWeb() != "#7c4dff" {
+		t.Errorf("expected #7c4dff, got: %s", c.AsWeb())
+	}
+	if c, _ := colors.FromName("red"); c.AsWeb() != "#ff0000" {
+		t.Errorf("expected #ff0000, got: %s", c.AsWeb())
+	}
+}
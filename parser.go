@@ -0,0 +1,483 @@
+package colors
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// DefaultParser is the default parser, used by [Parse], [ParseFloatColor],
+// [Color.UnmarshalText], and [Color.Pflag].
+var DefaultParser = NewParser()
+
+// RegisterFormat registers a format with the [DefaultParser]. See
+// [Parser.RegisterFormat].
+func RegisterFormat(name string, f func(string) (Color, bool)) {
+	DefaultParser.RegisterFormat(name, f)
+}
+
+// Parser is a color parser, with configurable formats and options.
+//
+// The built-in formats, in their default order, are:
+//
+//	var          - var() references, see [WithVarResolver]
+//	relative     - relative colors, see [FromRelative]
+//	color-mix    - color-mix() functions, see [FromColorMix]
+//	light-dark   - light-dark() functions, see [WithContext]
+//	web          - hex literals, see [FromWeb]
+//	name         - color names, see [FromName] and [WithRegistry]
+//	currentcolor - the currentcolor keyword, see [WithContext]
+//	system       - system colors (ex: Canvas), see [WithContext]
+//	rgb, rgba    - see [FromRGB] and [FromRGBA]
+//	hsl, hsla    - see [FromHSL] and [FromHSLA]
+//	hwb          - see [FromHWB]
+//	lab, lch     - see [FromLab] and [FromLCh]
+//	oklab        - see [FromOKLab]
+//	oklch        - see [FromOKLCH]
+//	color        - color() functions, see [FromColorFunc]
+//	hex          - hex() functions, see [FromHex]
+//	x11          - X11 color specifications, see [FromX11]
+//	xterm        - xterm 256 color indexes and ANSI color names, see
+//	               [FromXterm], [WithANSIPalette], and [WithXtermIndexes]
+//
+// See [WithFormats] for enabling and ordering formats, and
+// [Parser.RegisterFormat] for adding formats. A parser is safe for
+// concurrent use, including registering formats while parsing.
+type Parser struct {
+	// mu serializes changes to the options.
+	mu sync.Mutex
+	// o are the options, which are copied and replaced when changed, such
+	// that parsing does not need to lock.
+	o atomic.Pointer[options]
+}
+
+// NewParser creates a new color parser.
+func NewParser(opts ...Option) *Parser {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	p := new(Parser)
+	p.o.Store(o)
+	return p
+}
+
+// opts returns the parser's options.
+func (p *Parser) opts() *options {
+	if o := p.o.Load(); o != nil {
+		return o
+	}
+	return &zeroOptions
+}
+
+// zeroOptions are the options of a zero value parser.
+var zeroOptions options
+
+// With returns a copy of the parser with the options applied, or the parser
+// when there are no options.
+func (p *Parser) With(opts ...Option) *Parser {
+	if len(opts) == 0 {
+		return p
+	}
+	o := *p.opts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	q := new(Parser)
+	q.o.Store(&o)
+	return q
+}
+
+// RegisterFormat registers a named format with the parser, replacing any
+// existing format with the same name, including the built-in formats. New
+// formats are enabled, and are tried after the existing formats.
+//
+// The format func is passed the input string with leading and trailing
+// whitespace removed, and should return false when the input is not in the
+// format.
+func (p *Parser) RegisterFormat(name string, f func(string) (Color, bool)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	o := *p.opts()
+	formats := append([]format(nil), o.list()...)
+	fn := func(_ options, s string) (Color, *ParseError) {
+		if c, ok := f(s); ok {
+			return c, nil
+		}
+		return Color{}, errNoMatch
+	}
+	i := o.index(name)
+	if i == -1 {
+		formats = append(formats, format{name: name, color: fn})
+		if o.enabled != nil {
+			o.enabled = append(append([]string(nil), o.enabled...), name)
+		}
+	} else {
+		formats[i] = format{name: name, color: fn}
+	}
+	o.formats = formats
+	p.o.Store(&o)
+}
+
+// Formats returns the names of the parser's enabled formats, in the order
+// they are tried.
+func (p *Parser) Formats() []string {
+	var names []string
+	for _, f := range p.opts().enabledFormats() {
+		names = append(names, f.name)
+	}
+	return names
+}
+
+// Parse parses a color. See [Parse].
+func (p *Parser) Parse(s string) (Color, error) {
+	c, err := p.opts().parse(s)
+	if err != nil {
+		err.Input = s
+		return Color{}, err
+	}
+	return c, nil
+}
+
+// ParseBytes parses a color in b. See [ParseBytes].
+func (p *Parser) ParseBytes(b []byte) (Color, error) {
+	o := p.opts()
+	c, err := o.parse(o.bytesString(b))
+	if err != nil {
+		err.Input = string(b)
+		return Color{}, err
+	}
+	return c, nil
+}
+
+// ParseFloat parses a color, retaining precision. See [ParseFloatColor].
+func (p *Parser) ParseFloat(s string) (FloatColor, error) {
+	c, err := p.opts().parseFloat(s)
+	if err != nil {
+		err.Input = s
+		return FloatColor{}, err
+	}
+	return c, nil
+}
+
+// Pflag returns a [Pflag] wrapping the color that uses the parser.
+func (p *Parser) Pflag(c *Color) Pflag {
+	return Pflag{c, p}
+}
+
+// Option is a parse option.
+type Option func(*options)
+
+// WithLegacyAlpha is a parse option to set whether a rgb or rgba alpha
+// specified as a number is treated as 0-255 (ex: rgba(0, 0, 0, 128)), as
+// was the case with earlier versions of this package, instead of 0-1 as per
+// the CSS spec.
+func WithLegacyAlpha(legacyAlpha bool) Option {
+	return func(o *options) {
+		o.legacyAlpha = legacyAlpha
+	}
+}
+
+// WithByteOrder is a parse option to set the byte order of hex literals
+// (ex: #80ff0000 or 0x00e1e4ff), overriding the default byte order for the
+// literal's form. See [FromWeb] for the supported forms.
+//
+// Use [OrderARGB] for #aarrggbb colors from Android resources and Qt
+// stylesheets, and [OrderBGR] for Windows COLORREF values.
+func WithByteOrder(order ByteOrder) Option {
+	return func(o *options) {
+		o.order = &order
+	}
+}
+
+// WithVarResolver is a parse option to set a resolver for CSS custom
+// properties referenced by var(), ex: var(--brand) or var(--brand, red).
+// The resolver is passed the custom property name (ex: --brand), and should
+// return its value and whether it was found. Resolved values may be any
+// color supported by [Parse], including other var() references. When a
+// custom property is not found, the var() fallback, if any, is used.
+//
+// var() references may be used anywhere a color is expected, including as
+// the origin color of a relative color (ex: rgb(from var(--brand) r g b /
+// 50%)), and as the colors of color-mix().
+func WithVarResolver(resolve func(name string) (string, bool)) Option {
+	return func(o *options) {
+		o.resolve = resolve
+	}
+}
+
+// WithFormats is a parse option to set the enabled formats, in the order
+// they are tried, ex: WithFormats("web", "rgb") to only parse hex literals
+// and rgb functions. Unknown format names are ignored. See [Parser] for the
+// built-in format names.
+//
+// Formats used within other formats, such as the origin color of a relative
+// color, or the colors of color-mix(), are limited to the enabled formats.
+func WithFormats(names ...string) Option {
+	return func(o *options) {
+		o.enabled = append([]string{}, names...)
+	}
+}
+
+// WithScanNames is a scan option to set whether color names (ex: mistyrose)
+// are found when scanning text. See [Parser.Scan].
+func WithScanNames(scanNames bool) Option {
+	return func(o *options) {
+		o.scanNames = scanNames
+	}
+}
+
+// WithStrict is a parse option to set whether channels of the CSS color
+// functions (rgb, hsl, hwb, lab, lch, oklab, oklch, and color) that are
+// outside of their valid range are rejected, ex: rgb(300 0 0), instead of
+// being clamped as per the CSS spec.
+func WithStrict(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+// options are parse options.
+type options struct {
+	legacyAlpha  bool
+	order        *ByteOrder
+	resolve      func(string) (string, bool)
+	strict       bool
+	scanNames    bool
+	ctx          *Context
+	ansi         *ANSIPalette
+	xtermIndexes bool
+	registry     *Registry
+	// formats are the registered formats, or nil for the built-in formats.
+	formats []format
+	// enabled are the names of the enabled formats, in order, or nil for all
+	// formats.
+	enabled []string
+	// depth is the nesting depth of var() references, relative colors, and
+	// color-mix() functions.
+	depth int
+}
+
+// maxDepth is the maximum nesting depth of var() references, relative
+// colors, and color-mix() functions, which guards against cyclic var()
+// references.
+const maxDepth = 32
+
+// format is a color format.
+type format struct {
+	name string
+	// color parses the format as a color. When nil, float is used.
+	color func(options, string) (Color, *ParseError)
+	// float parses the format as a float color, retaining precision. When
+	// nil, color is used.
+	float func(options, string) (FloatColor, *ParseError)
+}
+
+// builtinFormats are the built-in formats.
+var builtinFormats []format
+
+func init() {
+	builtinFormats = []format{
+		{name: "var", float: options.parseVar},
+		{name: "relative", float: options.parseRelative},
+		{name: "color-mix", float: options.parseColorMix},
+		{name: "light-dark", float: options.parseLightDark},
+		{name: "web", color: options.fromWeb},
+		{name: "name", color: options.fromName},
+		{name: "currentcolor", color: options.parseCurrentColor},
+		{name: "system", color: options.parseSystem},
+		{name: "rgb", color: options.fromRGB},
+		{name: "rgba", color: options.fromRGBA},
+		{name: "hsl", color: options.fromHSL},
+		{name: "hsla", color: options.fromHSLA},
+		{name: "hwb", color: options.parseHWB},
+		{name: "lab", float: options.parseLab},
+		{name: "lch", float: options.parseLCh},
+		{name: "oklab", float: options.parseOKLab},
+		{name: "oklch", float: options.parseOKLCH},
+		{name: "color", float: options.parseColorFunc},
+		{name: "hex", color: options.fromHex},
+		{name: "x11", float: options.fromX11},
+		{name: "xterm", color: options.fromXterm},
+	}
+}
+
+// list returns the registered formats.
+func (o options) list() []format {
+	if o.formats == nil {
+		return builtinFormats
+	}
+	return o.formats
+}
+
+// index returns the index of the named format in the registered formats, or
+// -1.
+func (o options) index(name string) int {
+	for i, f := range o.list() {
+		if f.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// enabledFormats returns the enabled formats, in order.
+func (o options) enabledFormats() []format {
+	formats := o.list()
+	if o.enabled == nil {
+		return formats
+	}
+	var v []format
+	for _, name := range o.enabled {
+		if i := o.index(name); i != -1 {
+			v = append(v, formats[i])
+		}
+	}
+	return v
+}
+
+// enabledFormat returns the named format, when enabled.
+func (o options) enabledFormat(name string) (format, bool) {
+	for _, f := range o.enabledFormats() {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return format{}, false
+}
+
+// bytesString returns b as a string for parsing. The built-in formats do not
+// retain their input, so b is only copied when it may be passed to a
+// registered format or a var() resolver.
+func (o options) bytesString(b []byte) string {
+	if o.formats != nil || o.resolve != nil {
+		return string(b)
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// parse parses a color using the options. Errors are from the first format
+// that matched the input, or for an unknown color name.
+func (o options) parse(s string) (Color, *ParseError) {
+	if o.depth++; o.depth > maxDepth {
+		return Color{}, errorf(0, "too deeply nested")
+	}
+	s, off := trim(s)
+	var err *ParseError
+	for _, f := range o.enabledFormats() {
+		if f.color != nil {
+			c, e := f.color(o, s)
+			if e == nil {
+				if f.name != "name" {
+					c = o.named(c)
+				}
+				return c, nil
+			}
+			err = first(err, e, f.name)
+		} else {
+			c, e := f.float(o, s)
+			if e == nil {
+				return o.named(c.Color()), nil
+			}
+			err = first(err, e, f.name)
+		}
+	}
+	if err == nil {
+		err = o.unknown(s)
+	}
+	return Color{}, nested(err, off)
+}
+
+// named returns the color with its name from the registry. Colors are
+// created with the name from the [DefaultRegistry], so the name is only
+// looked up when the options have a registry.
+func (o options) named(c Color) Color {
+	if o.registry != nil {
+		c.NamedColor = o.registry.Name(c.R, c.G, c.B, c.A)
+	}
+	return c
+}
+
+// parseFloat parses a color using the options, retaining precision as with
+// [ParseFloatColor].
+func (o options) parseFloat(s string) (FloatColor, *ParseError) {
+	if o.depth++; o.depth > maxDepth {
+		return FloatColor{}, errorf(0, "too deeply nested")
+	}
+	s, off := trim(s)
+	var err *ParseError
+	for _, f := range o.enabledFormats() {
+		if f.float != nil {
+			c, e := f.float(o, s)
+			if e == nil {
+				return c, nil
+			}
+			err = first(err, e, f.name)
+		} else {
+			c, e := f.color(o, s)
+			if e == nil {
+				return c.Float(), nil
+			}
+			err = first(err, e, f.name)
+		}
+	}
+	if err == nil {
+		err = o.unknown(s)
+	}
+	return FloatColor{}, nested(err, off)
+}
+
+// trim returns s with leading and trailing whitespace removed, and the
+// number of leading whitespace bytes removed.
+func trim(s string) (string, int) {
+	t := strings.TrimLeft(s, " \t\n\r\f")
+	return strings.TrimRight(t, " \t\n\r\f"), len(s) - len(t)
+}
+
+// first returns err, or e when err is nil and e is not errNoMatch, with
+// the format name set when e does not have a format.
+func first(err, e *ParseError, name string) *ParseError {
+	if err != nil || e == errNoMatch {
+		return err
+	}
+	if e.Format == "" {
+		e.Format = name
+	}
+	return e
+}
+
+// fromWeb converts a web string to a color using the options.
+func (o options) fromWeb(s string) (Color, *ParseError) {
+	return parseHexLiteral(s, o.order)
+}
+
+// fromRGB converts a rgb string to a color using the options.
+func (o options) fromRGB(s string) (Color, *ParseError) {
+	return o.parseRGB(s, "rgb")
+}
+
+// fromRGBA converts a rgba string to a color using the options.
+func (o options) fromRGBA(s string) (Color, *ParseError) {
+	return o.parseRGB(s, "rgba")
+}
+
+// fromHSL converts a hsl string to a color using the options.
+func (o options) fromHSL(s string) (Color, *ParseError) {
+	return o.parseHSL(s, "hsl")
+}
+
+// fromHSLA converts a hsla string to a color using the options.
+func (o options) fromHSLA(s string) (Color, *ParseError) {
+	return o.parseHSL(s, "hsla")
+}
+
+// fromHex converts a hex() string to a color using the options.
+func (o options) fromHex(s string) (Color, *ParseError) {
+	return parseHexFunc(s)
+}
+
+// fromX11 converts a X11 color specification to a color using the options.
+func (o options) fromX11(s string) (FloatColor, *ParseError) {
+	return parseX11(s)
+}
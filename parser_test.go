@@ -0,0 +1,499 @@
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kenshaw/colors/strcase"
+)
+
+func TestParserFormats(t *testing.T) {
+	tests := []struct {
+		s   string
+		fs  []string
+		exp string
+	}{
+		{"red", nil, "#ff0000"},
+		{"red", []string{"web", "rgb"}, ""},
+		{"#ff0000", []string{"web", "rgb"}, "#ff0000"},
+		{"rgb(255 0 0)", []string{"web", "rgb"}, "#ff0000"},
+		{"hsl(0 100% 50%)", []string{"web", "rgb"}, ""},
+		{"rgb(from red r g b)", []string{"relative", "rgb"}, ""},
+		{"rgb(from red r g b)", []string{"relative", "name"}, "#ff0000"},
+		{"color-mix(in srgb, red, blue)", []string{"color-mix", "name"}, "#800080"},
+		{"color-mix(in srgb, red, #0000ff)", []string{"color-mix", "name"}, ""},
+		{"rgb:ffff/0/0", []string{"x11"}, "#ff0000"},
+		{"rgb:ffff/0/0", []string{"unknown", "x11"}, "#ff0000"},
+		{"red", []string{}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			var opts []Option
+			if test.fs != nil {
+				opts = append(opts, WithFormats(test.fs...))
+			}
+			c, err := NewParser(opts...).Parse(test.s)
+			switch {
+			case test.exp == "" && err == nil:
+				t.Fatalf("expected error, got: %s", c.AsWeb())
+			case test.exp == "":
+				if !errors.Is(err, ErrInvalidColor) {
+					t.Errorf("expected ErrInvalidColor, got: %v", err)
+				}
+			case err != nil:
+				t.Fatalf("expected no error, got: %v", err)
+			case c.AsWeb() != test.exp:
+				t.Errorf("expected %s, got: %s", test.exp, c.AsWeb())
+			}
+		})
+	}
+}
+
+func TestParserFormatsList(t *testing.T) {
+	exp := []string{
+		"var", "relative", "color-mix", "light-dark", "web", "name",
+		"currentcolor", "system", "rgb", "rgba", "hsl", "hsla", "hwb", "lab",
+		"lch", "oklab", "oklch", "color", "hex", "x11", "xterm",
+	}
+	if v := NewParser().Formats(); !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	if v, exp := NewParser(WithFormats("rgb", "web", "unknown")).Formats(), []string{"rgb", "web"}; !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+}
+
+func TestParserRegisterFormat(t *testing.T) {
+	brand := func(s string) (Color, bool) {
+		if strings.EqualFold(s, "brand") {
+			return New(0x12, 0x34, 0x56, 0xff), true
+		}
+		return Color{}, false
+	}
+	p := NewParser()
+	p.RegisterFormat("brand", brand)
+	if v, exp := p.Formats(), "brand"; v[len(v)-1] != exp {
+		t.Errorf("expected last format %q, got: %v", exp, v)
+	}
+	for _, s := range []string{"brand", " BRAND ", "color-mix(in srgb, brand, brand)", "rgb(from brand r g b)"} {
+		c, err := p.Parse(s)
+		if err != nil {
+			t.Fatalf("%q: expected no error, got: %v", s, err)
+		}
+		if v, exp := c.AsWeb(), "#123456"; v != exp {
+			t.Errorf("%q: expected %s, got: %s", s, exp, v)
+		}
+	}
+	// registering with a parser does not change other parsers
+	if _, err := Parse("brand"); err == nil {
+		t.Errorf("expected error")
+	}
+	if _, err := p.With(WithFormats("name")).Parse("brand"); err == nil {
+		t.Errorf("expected error")
+	}
+	// enabled formats include new formats
+	q := NewParser(WithFormats("name"))
+	q.RegisterFormat("brand", brand)
+	if v, exp := q.Formats(), []string{"name", "brand"}; !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	// replacing a built-in format retains its position
+	q = NewParser()
+	q.RegisterFormat("name", func(s string) (Color, bool) {
+		if s == "red" {
+			return New(0xee, 0, 0, 0xff), true
+		}
+		return Color{}, false
+	})
+	if v, exp := q.Formats(), NewParser().Formats(); !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+	c, err := q.Parse("red")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if v, exp := c.AsWeb(), "#ee0000"; v != exp {
+		t.Errorf("expected %s, got: %s", exp, v)
+	}
+	if _, err := q.Parse("blue"); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestParserRegisterFormatConcurrent(t *testing.T) {
+	p := NewParser()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 20 {
+				name := fmt.Sprintf("brand-%d-%d", i, j)
+				p.RegisterFormat(name, func(s string) (Color, bool) {
+					return New(uint8(i), uint8(j), 0, 0xff), s == name
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if _, err := p.Parse("mistyrose"); err != nil {
+					t.Errorf("expected no error, got: %v", err)
+				}
+				_ = p.Scan("#fff rgb(0 0 0)")
+			}
+		}()
+	}
+	wg.Wait()
+	if n, exp := len(p.Formats()), len(NewParser().Formats())+8*20; n != exp {
+		t.Errorf("expected %d formats, got: %d", exp, n)
+	}
+	if c, err := p.Parse("brand-7-19"); err != nil || c.AsWeb() != "#071300" {
+		t.Errorf("expected #071300, got: %v %v", c, err)
+	}
+}
+
+func TestParserStrict(t *testing.T) {
+	tests := []struct {
+		s      string
+		opts   []Option
+		offset int
+		format string
+		reason string
+	}{
+		{"rgb(300 0 0)", nil, 4, "rgb", "channel 1 out of range 0-255"},
+		{"rgb(0, 0, -1)", nil, 10, "rgb", "channel 3 out of range 0-255"},
+		{"rgb(0 120% 0)", nil, 6, "rgb", "channel 2 out of range 0%-100%"},
+		{"rgba(0 0 0 / 2)", nil, 13, "rgba", "alpha out of range 0-1"},
+		{"rgba(0, 0, 0, 300)", []Option{WithLegacyAlpha(true)}, 14, "rgba", "alpha out of range 0-255"},
+		{"hsl(0 120% 50%)", nil, 6, "hsl", "channel 2 out of range 0%-100%"},
+		{"hwb(0 0% -10%)", nil, 9, "hwb", "channel 3 out of range 0%-100%"},
+		{"lab(110 0 0)", nil, 4, "lab", "channel 1 out of range 0-100"},
+		{"lch(50 -1 0)", nil, 7, "lch", "channel 2 out of range 0 or greater"},
+		{"oklab(1.5 0 0)", nil, 6, "oklab", "channel 1 out of range 0-1"},
+		{"oklch(0.5 -10% 0)", nil, 10, "oklch", "channel 2 out of range 0% or greater"},
+		{"color(srgb 1.2 0 0)", nil, 11, "color", "channel 1 out of range 0-1"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			if _, err := NewParser(test.opts...).Parse(test.s); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			p := NewParser(append(test.opts, WithStrict(true))...)
+			_, err := p.Parse(test.s)
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected *ParseError, got: %v", err)
+			}
+			if perr.Offset != test.offset || perr.Format != test.format || perr.Reason != test.reason {
+				t.Errorf("expected %d %s %q, got: %d %s %q", test.offset, test.format, test.reason, perr.Offset, perr.Format, perr.Reason)
+			}
+			if _, err := p.ParseFloat(test.s); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+	for _, s := range []string{
+		"rgb(255 0 0)", "rgb(100% 0% 0%)", "hsl(720 100% 50%)", "lab(50 -160 160)",
+		"oklch(0.5 0.2 -30)", "color(xyz 1.2 0 0)", "rgb(from red 300 g b)", "red",
+	} {
+		if _, err := NewParser(WithStrict(true)).Parse(s); err != nil {
+			t.Errorf("%q: expected no error, got: %v", s, err)
+		}
+	}
+}
+
+func TestParserPflag(t *testing.T) {
+	p := NewParser(WithFormats("web"))
+	var c Color
+	v := p.Pflag(&c)
+	if err := v.Set("red"); err == nil {
+		t.Errorf("expected error")
+	}
+	if err := v.Set("#ff0000"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if exp := New(0xff, 0, 0, 0xff); c != exp {
+		t.Errorf("expected %v, got: %v", exp, c)
+	}
+	if err := v.UnmarshalText([]byte("#00ff00")); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, exp := v.String(), "lime"; s != exp {
+		t.Errorf("expected %s, got: %s", exp, s)
+	}
+	if err := c.Pflag().Set("red"); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+// parseBenchmarks are the inputs for the parse benchmarks.
+var parseBenchmarks = []struct {
+	name string
+	s    string
+}{
+	{"name", "mistyrose"},
+	{"name_mixed", "Misty_Rose"},
+	{"web", "#ffe4e1"},
+	{"web_short", "#fe1"},
+	{"hex_int", "0xffffe4e1"},
+	{"rgb", "rgb(255, 228, 225)"},
+	{"rgb_modern", "rgb(100% 89.41% 88.24% / 50%)"},
+	{"rgba", "rgba(255, 228, 225, 0.5)"},
+	{"hex", "hex(ff, e4, e1)"},
+	{"hsl", "hsl(6, 100%, 94.12%)"},
+	{"hwb", "hwb(6 88.24% 0%)"},
+	{"lab", "lab(92.76 9.2 5.03)"},
+	{"oklch", "oklch(94.001% 0.03008 25.281)"},
+	{"color", "color(srgb 1 0.8941 0.8824)"},
+	{"x11", "rgb:ffff/e4e4/e1e1"},
+	{"long", "color(display-p3 0.9876543210 0.8876543210 0.8776543210 / 0.9876543210)"},
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, bench := range parseBenchmarks {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := Parse(bench.s); err != nil {
+					b.Fatalf("expected no error, got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	for _, bench := range parseBenchmarks {
+		buf := []byte(bench.s)
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := ParseBytes(buf); err != nil {
+					b.Fatalf("expected no error, got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParseBaseline benchmarks the regexp based parser that was
+// replaced by the current parser, for comparison with [BenchmarkParse].
+// Inputs that the baseline parser does not support are skipped.
+func BenchmarkParseBaseline(b *testing.B) {
+	for _, bench := range parseBenchmarks {
+		b.Run(bench.name, func(b *testing.B) {
+			if _, err := baselineParse(bench.s); err != nil {
+				b.Skip("not supported")
+			}
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := baselineParse(bench.s); err != nil {
+					b.Fatalf("expected no error, got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// baselineParse is the regexp based parser that was replaced by the current
+// parser, parsing color names, and the web, rgb, rgba, and hex formats.
+func baselineParse(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, f := range []func(string) (Color, bool){
+		baselineFromWeb,
+		baselineFromName,
+		func(s string) (Color, bool) { return baselineFromRE(s, baselineRGBRE, baselineParseDec, false) },
+		func(s string) (Color, bool) { return baselineFromRE(s, baselineRGBARE, baselineParseDec, false) },
+		func(s string) (Color, bool) { return baselineFromRE(s, baselineHexRE, baselineParseHex, true) },
+	} {
+		if c, ok := f(s); ok {
+			return c, nil
+		}
+	}
+	return Color{}, ErrInvalidColor
+}
+
+// baselineFromWeb converts a web string to a color.
+func baselineFromWeb(s string) (c Color, ok bool) {
+	c.A = 0xff
+	n := len(s)
+	if n == 0 || s[0] != '#' {
+		return c, false
+	}
+	hexToByte := func(b byte) byte {
+		switch {
+		case b >= '0' && b <= '9':
+			return b - '0'
+		case b >= 'a' && b <= 'f':
+			return b - 'a' + 10
+		case b >= 'A' && b <= 'F':
+			return b - 'A' + 10
+		}
+		ok = false
+		return 0
+	}
+	switch ok = true; n {
+	case 9:
+		c.R = hexToByte(s[1])<<4 + hexToByte(s[2])
+		c.G = hexToByte(s[3])<<4 + hexToByte(s[4])
+		c.B = hexToByte(s[5])<<4 + hexToByte(s[6])
+		c.A = hexToByte(s[7])<<4 + hexToByte(s[8])
+	case 7:
+		c.R = hexToByte(s[1])<<4 + hexToByte(s[2])
+		c.G = hexToByte(s[3])<<4 + hexToByte(s[4])
+		c.B = hexToByte(s[5])<<4 + hexToByte(s[6])
+	case 4:
+		c.R = hexToByte(s[1]) * 17
+		c.G = hexToByte(s[2]) * 17
+		c.B = hexToByte(s[3]) * 17
+	default:
+		ok = false
+	}
+	if ok {
+		c = New(c.R, c.G, c.B, c.A)
+	}
+	return
+}
+
+// baselineFromName converts a name to a color.
+func baselineFromName(s string) (Color, bool) {
+	n := strings.ToLower(strings.TrimSpace(strcase.ForceCamelIdentifier(s)))
+	if c, ok := baselineNames[NamedColor(n)]; ok {
+		return ToColor(c.R, c.G, c.B, c.A, n), true
+	}
+	return Color{}, false
+}
+
+// baselineFromRE parses all regexp matches with f.
+func baselineFromRE(s string, re *regexp.Regexp, f func(string) (uint8, bool), lastOptional bool) (Color, bool) {
+	m := re.FindStringSubmatch(s)
+	n := len(m)
+	if n != 4 && n != 5 {
+		return Color{}, false
+	}
+	var c Color
+	var ok bool
+	if c.R, ok = f(m[1]); !ok {
+		return Color{}, false
+	}
+	if c.G, ok = f(m[2]); !ok {
+		return Color{}, false
+	}
+	if c.B, ok = f(m[3]); !ok {
+		return Color{}, false
+	}
+	switch {
+	case !lastOptional && n == 5 && m[4] == "":
+		return Color{}, false
+	case n == 5 && m[4] != "":
+		if c.A, ok = f(m[4]); !ok {
+			return Color{}, false
+		}
+	default:
+		c.A = 0xff
+	}
+	return New(c.R, c.G, c.B, c.A), true
+}
+
+// baselineParseDec parses a decimal number in s.
+func baselineParseDec(s string) (uint8, bool) {
+	u, err := strconv.ParseUint(s, 10, 8)
+	return uint8(u), err == nil
+}
+
+// baselineParseHex parses a hex number in s.
+func baselineParseHex(s string) (uint8, bool) {
+	u, err := strconv.ParseUint(s, 16, 8)
+	return uint8(u), err == nil
+}
+
+// baseline regexps and names.
+var (
+	baselineRGBRE  = regexp.MustCompile(`(?i)^rgb\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)$`)
+	baselineRGBARE = regexp.MustCompile(`(?i)^rgba\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)$`)
+	baselineHexRE  = regexp.MustCompile(`(?i)^hex\(\s*([0-9a-f]{1,2})\s*,\s*([0-9a-f]{1,2})\s*,\s*([0-9a-f]{1,2})\s*(?:,\s*([0-9a-f]{1,2})\s*)?\)$`)
+	baselineNames  = DefaultRegistry.Map()
+)
+
+func TestParseAllocs(t *testing.T) {
+	for _, test := range parseBenchmarks {
+		t.Run(test.name, func(t *testing.T) {
+			buf := []byte(test.s)
+			if n := testing.AllocsPerRun(100, func() {
+				_, _ = Parse(test.s)
+			}); n != 0 {
+				t.Errorf("expected Parse to not allocate, got: %v", n)
+			}
+			if n := testing.AllocsPerRun(100, func() {
+				_, _ = ParseBytes(buf)
+			}); n != 0 {
+				t.Errorf("expected ParseBytes to not allocate, got: %v", n)
+			}
+		})
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	buf := []byte("Misty_Rose")
+	c, err := ParseBytes(buf)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	// the color must not retain the input
+	copy(buf, "XXXXXXXXXX")
+	if exp := Mistyrose.Color(); c != exp {
+		t.Errorf("expected %#v, got: %#v", exp, c)
+	}
+	buf = []byte("rgb(300 0 0)")
+	_, err = ParseBytes(buf, WithStrict(true))
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected *ParseError, got: %v", err)
+	}
+	copy(buf, "XXXXXXXXXXXX")
+	if exp := "rgb(300 0 0)"; perr.Input != exp {
+		t.Errorf("expected input %q, got: %q", exp, perr.Input)
+	}
+	// var() names are passed to the resolver
+	var names []string
+	c, err = ParseBytes([]byte("var(--brand)"), WithVarResolver(func(name string) (string, bool) {
+		names = append(names, name)
+		return "red", true
+	}))
+	switch {
+	case err != nil:
+		t.Fatalf("expected no error, got: %v", err)
+	case c != Red.Color():
+		t.Errorf("expected %#v, got: %#v", Red.Color(), c)
+	case !reflect.DeepEqual(names, []string{"--brand"}):
+		t.Errorf("expected [--brand], got: %v", names)
+	}
+}
+
+func TestAppendName(t *testing.T) {
+	for _, s := range []string{
+		"", "red", "Misty_Rose", "misty-rose", "MISTY ROSE", "mistyRose",
+		" LightGoldenRodYellow ", "1red", "_2_red", "red2", "gray47",
+		"navy blue", "rgb(255, 0, 0)", "#ff0000", "ID", "HTTPServer", "-_-",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, ok := appendName(nil, s, len(s))
+			if !ok {
+				t.Fatalf("expected ok")
+			}
+			if exp := strings.ToLower(strcase.ForceCamelIdentifier(s)); string(v) != exp {
+				t.Errorf("expected %q, got: %q", exp, string(v))
+			}
+		})
+	}
+	if _, ok := appendName(nil, "röd", 3); ok {
+		t.Errorf("expected not ok")
+	}
+}
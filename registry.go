@@ -0,0 +1,389 @@
+package colors
+
+import (
+	"image/color"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kenshaw/colors/strcase"
+)
+
+// DefaultRegistry is the default named color registry, used by [Register],
+// [RegisterName], [FromName], [New], and the [NamedColor] methods.
+var DefaultRegistry = NewRegistry()
+
+// Registry is a registry of named colors, with a reverse lookup of colors to
+// their names. A registry is safe for concurrent use.
+//
+// Use [NewRegistry] or [Registry.Clone] to create registries isolated from
+// the [DefaultRegistry], and [WithRegistry] to parse using a registry.
+type Registry struct {
+	mu sync.RWMutex
+	// colors are the named colors.
+	colors map[NamedColor]color.NRGBA
+	// names are the names, keyed by their normalized form. See [appendName].
+	names map[NamedColor]NamedColor
+	// lookup are the canonical names, keyed by their color. See [mapKey].
+	lookup map[uint32]NamedColor
+	// preferred are the preferred names, keyed by their color. See
+	// [Registry.Prefer].
+	preferred map[uint32]NamedColor
+	// maxNameLen is the length of the longest normalized name.
+	maxNameLen int
+	// trees are the k-d trees of the registered colors, keyed by their
+	// metric and alpha mode. See [Registry.Nearest].
+	trees map[treeKey]*kdTree
+	// locales are the registered locales, keyed by their language. See
+	// [Registry.RegisterLocale].
+	locales map[string]*Locale
+	// langs are the languages of the registered locales, sorted.
+	langs []string
+}
+
+// NewRegistry creates a new named color registry with the CSS named colors.
+//
+// The canonical names of CSS named colors with more than one name are the
+// CSS basic color names (aqua and fuchsia), and the American spellings (ex:
+// gray). See [Registry.Prefer] and [Registry.SetSpelling] for changing the
+// canonical names.
+func NewRegistry() *Registry {
+	r := &Registry{
+		colors:    make(map[NamedColor]color.NRGBA, len(colors)),
+		names:     make(map[NamedColor]NamedColor, len(colors)),
+		lookup:    make(map[uint32]NamedColor, len(colors)),
+		preferred: make(map[uint32]NamedColor),
+	}
+	for n, c := range colors {
+		r.colors[n] = c
+		r.names[n] = n
+		if !isAlias(n) {
+			r.lookup[mapKey(c.R, c.G, c.B, c.A)] = n
+		}
+		r.maxNameLen = max(r.maxNameLen, len(n))
+	}
+	return r
+}
+
+// Clone returns a copy of the registry.
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	q := &Registry{
+		colors:     make(map[NamedColor]color.NRGBA, len(r.colors)),
+		names:      make(map[NamedColor]NamedColor, len(r.names)),
+		lookup:     make(map[uint32]NamedColor, len(r.lookup)),
+		preferred:  make(map[uint32]NamedColor, len(r.preferred)),
+		maxNameLen: r.maxNameLen,
+		locales:    make(map[string]*Locale, len(r.locales)),
+		langs:      append([]string(nil), r.langs...),
+	}
+	for k, v := range r.colors {
+		q.colors[k] = v
+	}
+	for k, v := range r.names {
+		q.names[k] = v
+	}
+	for k, v := range r.lookup {
+		q.lookup[k] = v
+	}
+	for k, v := range r.preferred {
+		q.preferred[k] = v
+	}
+	for k, v := range r.locales {
+		q.locales[k] = v
+	}
+	return q
+}
+
+// Register registers a named color. The name is found by [Registry.FromName]
+// using its normalized form, such that a color registered as slate-900 is
+// found with slate-900, Slate_900, or slate900. Replaces any color
+// registered with the same normalized name.
+//
+// The name becomes the canonical name for the color, unless another name
+// for the color is preferred. See [Registry.Prefer].
+func (r *Registry) Register(n NamedColor, clr color.Color) {
+	c := toNRGBA(clr)
+	key := nameKey(string(n))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unregister(key)
+	r.trees = nil
+	r.colors[n] = c
+	r.names[key] = n
+	r.maxNameLen = max(r.maxNameLen, len(key))
+	k := mapKey(c.R, c.G, c.B, c.A)
+	if p, ok := r.preferred[k]; !ok || p == n || !r.has(p, c) {
+		r.lookup[k] = n
+	}
+}
+
+// RegisterName registers a named color. See [Registry.Register].
+func (r *Registry) RegisterName(s string, clr color.Color) {
+	r.Register(NamedColor(s), clr)
+}
+
+// Unregister unregisters the named color, returning false when the name is
+// not registered. Names are normalized as with [Registry.FromName].
+//
+// When other names are registered for the same color, the color's canonical
+// name becomes the preferred name, if any, or the first of the other names
+// that is not an alias of a CSS named color (ex: grey), in sort order.
+func (r *Registry) Unregister(s string) bool {
+	key := nameKey(s)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.unregister(key)
+}
+
+// unregister unregisters the name with the normalized key. The caller must
+// hold the write lock.
+func (r *Registry) unregister(key NamedColor) bool {
+	n, ok := r.names[key]
+	if !ok {
+		return false
+	}
+	c := r.colors[n]
+	r.trees = nil
+	delete(r.names, key)
+	delete(r.colors, n)
+	k := mapKey(c.R, c.G, c.B, c.A)
+	if r.lookup[k] != n {
+		return true
+	}
+	delete(r.lookup, k)
+	if p, ok := r.preferred[k]; ok && r.has(p, c) {
+		r.lookup[k] = p
+		return true
+	}
+	for name, v := range r.colors {
+		if v == c && (r.lookup[k] == "" || less(name, r.lookup[k])) {
+			r.lookup[k] = name
+		}
+	}
+	return true
+}
+
+// has returns true when the name is registered with the color. The caller
+// must hold the lock.
+func (r *Registry) has(n NamedColor, c color.NRGBA) bool {
+	v, ok := r.colors[n]
+	return ok && v == c
+}
+
+// less returns true when a is ordered before b as a canonical name, where
+// names that are not aliases of CSS named colors are first, and are
+// otherwise in sort order.
+func less(a, b NamedColor) bool {
+	if x, y := isAlias(a), isAlias(b); x != y {
+		return y
+	}
+	return a < b
+}
+
+// isAlias returns true when the name is an alias of a CSS named color, ex:
+// grey.
+func isAlias(n NamedColor) bool {
+	for _, v := range aliases {
+		for _, alias := range v {
+			if n == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Aliases returns the other names registered for the named color's color,
+// sorted, ex: grey for gray. Returns nil when the name is not registered.
+func (r *Registry) Aliases(name NamedColor) []NamedColor {
+	r.mu.RLock()
+	n, ok := r.names[nameKey(string(name))]
+	if !ok {
+		r.mu.RUnlock()
+		return nil
+	}
+	c := r.colors[n]
+	var v []NamedColor
+	for k, x := range r.colors {
+		if x == c && k != n {
+			v = append(v, k)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(v, func(i, j int) bool {
+		return v[i] < v[j]
+	})
+	return v
+}
+
+// Canonical returns the canonical name for the named color's color, as
+// returned by [Registry.New], ex: gray for grey. Returns an empty string when
+// the name is not registered.
+func (r *Registry) Canonical(name NamedColor) NamedColor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n, ok := r.names[nameKey(string(name))]
+	if !ok {
+		return ""
+	}
+	c := r.colors[n]
+	return r.lookup[mapKey(c.R, c.G, c.B, c.A)]
+}
+
+// Prefer sets the names as the canonical names for their colors, ex:
+// Prefer(Cyan, Magenta). Preferred names remain canonical when other names
+// are registered for the same color. Unregistered names are ignored.
+func (r *Registry) Prefer(names ...NamedColor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		n, ok := r.names[nameKey(string(name))]
+		if !ok {
+			continue
+		}
+		c := r.colors[n]
+		k := mapKey(c.R, c.G, c.B, c.A)
+		r.preferred[k], r.lookup[k] = n, n
+		r.trees = nil
+	}
+}
+
+// Spelling is a spelling preference for the canonical names of the CSS named
+// colors with both American and British spellings, ex: gray and grey.
+type Spelling int
+
+// Spellings.
+const (
+	SpellingAmerican Spelling = iota
+	SpellingBritish
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (spelling Spelling) String() string {
+	if spelling == SpellingBritish {
+		return "british"
+	}
+	return "american"
+}
+
+// SetSpelling sets the spelling of the canonical names of the CSS named
+// colors with both American and British spellings, ex: grey with
+// [SpellingBritish]. See [Registry.Prefer].
+func (r *Registry) SetSpelling(spelling Spelling) {
+	var names []NamedColor
+	for n, v := range aliases {
+		switch {
+		case !strings.Contains(string(n), "gray"):
+		case spelling == SpellingBritish:
+			names = append(names, v...)
+		default:
+			names = append(names, n)
+		}
+	}
+	r.Prefer(names...)
+}
+
+// Names returns the registered names, sorted.
+func (r *Registry) Names() []NamedColor {
+	r.mu.RLock()
+	v := make([]NamedColor, 0, len(r.colors))
+	for n := range r.colors {
+		v = append(v, n)
+	}
+	r.mu.RUnlock()
+	sort.Slice(v, func(i, j int) bool {
+		return v[i] < v[j]
+	})
+	return v
+}
+
+// Map returns a map of the registered named colors.
+func (r *Registry) Map() map[NamedColor]Color {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m := make(map[NamedColor]Color, len(r.colors))
+	for n, c := range r.colors {
+		m[n] = Color{c.R, c.G, c.B, c.A, n}
+	}
+	return m
+}
+
+// FromName converts a name to a color, using the registry. See [FromName].
+//
+// Names not otherwise found are looked up in the registered locales. See
+// [Registry.RegisterLocale].
+func (r *Registry) FromName(s string) (Color, bool) {
+	var buf [32]byte
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// keys longer than the longest name cannot match
+	key, ok := appendName(buf[:0], s, r.maxNameLen)
+	if !ok {
+		key = []byte(strings.ToLower(strings.TrimSpace(strcase.ForceCamelIdentifier(s))))
+	}
+	if n, ok := r.names[NamedColor(key)]; ok {
+		c := r.colors[n]
+		return Color{c.R, c.G, c.B, c.A, n}, true
+	}
+	if n, ok := r.fromLocale(s); ok {
+		c := r.colors[n]
+		return Color{c.R, c.G, c.B, c.A, n}, true
+	}
+	return Color{}, false
+}
+
+// New creates a new color, and looks up the named color in the registry.
+func (r *Registry) New(red, green, blue, alpha uint8) Color {
+	return Color{red, green, blue, alpha, r.Name(red, green, blue, alpha)}
+}
+
+// Name returns the registered name for the color, or an empty string.
+func (r *Registry) Name(red, green, blue, alpha uint8) NamedColor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lookup[mapKey(red, green, blue, alpha)]
+}
+
+// color returns the named color, without normalizing the name.
+func (r *Registry) color(n NamedColor) (color.NRGBA, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.colors[n]
+	return c, ok
+}
+
+// each calls f with each registered named color.
+func (r *Registry) each(f func(NamedColor, color.NRGBA)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for n, c := range r.colors {
+		f(n, c)
+	}
+}
+
+// WithRegistry is a parse option to set the named color registry used to
+// parse color names, and to look up the names of parsed colors. Without a
+// registry, the [DefaultRegistry] is used.
+func WithRegistry(r *Registry) Option {
+	return func(o *options) {
+		o.registry = r
+	}
+}
+
+// names returns the registry for the options.
+func (o options) names() *Registry {
+	if o.registry == nil {
+		return DefaultRegistry
+	}
+	return o.registry
+}
+
+// fromName converts a name to a color using the options.
+func (o options) fromName(s string) (Color, *ParseError) {
+	if c, ok := o.names().FromName(s); ok {
+		return c, nil
+	}
+	return Color{}, errNoMatch
+}
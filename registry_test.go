@@ -0,0 +1,249 @@
+package colors
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	brand := color.NRGBA{0x12, 0x34, 0x56, 0xff}
+	r.RegisterName("brand-primary", brand)
+	if c, ok := r.FromName("Brand_Primary"); !ok || c.NRGBA() != brand || c.Name() != "brand-primary" {
+		t.Errorf("expected brand-primary, got: %v %t", c, ok)
+	}
+	if c := r.New(0x12, 0x34, 0x56, 0xff); c.Name() != "brand-primary" {
+		t.Errorf("expected brand-primary, got: %q", c.Name())
+	}
+	// the default registry is unchanged
+	if _, ok := FromName("brand-primary"); ok {
+		t.Errorf("expected not ok")
+	}
+	if c := New(0x12, 0x34, 0x56, 0xff); c.Name() != "" {
+		t.Errorf("expected no name, got: %q", c.Name())
+	}
+	// parse
+	for _, s := range []string{"brand-primary", "#123456", "rgb(18 52 86)", "rgb(from brand-primary r g b)"} {
+		c, err := Parse(s, WithRegistry(r))
+		if err != nil {
+			t.Fatalf("%q: expected no error, got: %v", s, err)
+		}
+		if c.Name() != "brand-primary" {
+			t.Errorf("%q: expected brand-primary, got: %q", s, c.Name())
+		}
+	}
+	if _, err := Parse("brand-primary"); err == nil {
+		t.Errorf("expected error")
+	}
+	var perr *ParseError
+	if _, err := Parse("brand-primery", WithRegistry(r)); err == nil {
+		t.Errorf("expected error")
+	} else if perr = err.(*ParseError); len(perr.Suggestions) == 0 || perr.Suggestions[0] != "brand-primary" {
+		t.Errorf("expected brand-primary suggestion, got: %v", perr.Suggestions)
+	}
+	// replace
+	r.RegisterName("BRAND primary", color.NRGBA{0x65, 0x43, 0x21, 0xff})
+	if c, ok := r.FromName("brand-primary"); !ok || c.AsWeb() != "#654321" || c.Name() != "BRAND primary" {
+		t.Errorf("expected #654321, got: %v %t", c, ok)
+	}
+	if c := r.New(0x12, 0x34, 0x56, 0xff); c.Name() != "" {
+		t.Errorf("expected no name, got: %q", c.Name())
+	}
+}
+
+func TestRegistryUnregister(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterName("silver-gray", Gray)
+	if c := r.New(0x80, 0x80, 0x80, 0xff); c.Name() != "silver-gray" {
+		t.Errorf("expected silver-gray, got: %q", c.Name())
+	}
+	if !r.Unregister("Silver Gray") {
+		t.Fatalf("expected true")
+	}
+	if r.Unregister("silver-gray") {
+		t.Errorf("expected false")
+	}
+	if _, ok := r.FromName("silver-gray"); ok {
+		t.Errorf("expected not ok")
+	}
+	if c := r.New(0x80, 0x80, 0x80, 0xff); c.Name() != "gray" {
+		t.Errorf("expected gray, got: %q", c.Name())
+	}
+	r.Unregister("gray")
+	if c := r.New(0x80, 0x80, 0x80, 0xff); c.Name() != "grey" {
+		t.Errorf("expected grey, got: %q", c.Name())
+	}
+	if _, ok := FromName("gray"); !ok {
+		t.Errorf("expected ok")
+	}
+}
+
+func TestRegistryClone(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterName("a", Red)
+	q := r.Clone()
+	q.RegisterName("b", Blue)
+	r.Unregister("red")
+	if _, ok := q.FromName("red"); !ok {
+		t.Errorf("expected ok")
+	}
+	if _, ok := r.FromName("b"); ok {
+		t.Errorf("expected not ok")
+	}
+	names := q.Names()
+	if !sort.SliceIsSorted(names, func(i, j int) bool { return names[i] < names[j] }) {
+		t.Errorf("expected sorted names")
+	}
+	if n, exp := len(names), len(colors)+2; n != exp {
+		t.Errorf("expected %d names, got: %d", exp, n)
+	}
+	if n, exp := len(r.Map()), len(colors); n != exp {
+		t.Errorf("expected %d colors, got: %d", exp, n)
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := NewRegistry()
+	p := NewParser(WithRegistry(r))
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				r.RegisterName(fmt.Sprintf("c-%d-%d", i, j), color.NRGBA{uint8(i), uint8(j), 0, 0xff})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if _, err := p.Parse("mistyrose"); err != nil {
+					t.Errorf("expected no error, got: %v", err)
+				}
+				_ = r.New(uint8(i), 0, 0, 0xff)
+				_ = r.Suggest("misty", 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n, exp := len(r.Names()), len(colors)+8*100; n != exp {
+		t.Errorf("expected %d names, got: %d", exp, n)
+	}
+}
+
+func TestRegistryAliases(t *testing.T) {
+	// every css color with more than one name has a canonical name
+	m := make(map[uint32][]NamedColor)
+	for n, c := range colors {
+		k := mapKey(c.R, c.G, c.B, c.A)
+		m[k] = append(m[k], n)
+	}
+	for _, v := range m {
+		if len(v) == 1 {
+			continue
+		}
+		var canonical []NamedColor
+		for _, n := range v {
+			if !isAlias(n) {
+				canonical = append(canonical, n)
+			}
+		}
+		if len(canonical) != 1 {
+			t.Errorf("expected 1 canonical name for %v, got: %v", v, canonical)
+		}
+	}
+	tests := []struct {
+		n         NamedColor
+		canonical NamedColor
+		aliases   []NamedColor
+	}{
+		{Gray, Gray, []NamedColor{Grey}},
+		{Grey, Gray, []NamedColor{Gray}},
+		{Cyan, Aqua, []NamedColor{Aqua}},
+		{Magenta, Fuchsia, []NamedColor{Fuchsia}},
+		{Darkslategrey, Darkslategray, []NamedColor{Darkslategray}},
+		{Red, Red, nil},
+		{"Dark Slate Grey", Darkslategray, []NamedColor{Darkslategray}},
+		{"unknown", "", nil},
+	}
+	for _, test := range tests {
+		t.Run(string(test.n), func(t *testing.T) {
+			if v := test.n.Canonical(); v != test.canonical {
+				t.Errorf("expected %q, got: %q", test.canonical, v)
+			}
+			if v := test.n.Aliases(); !reflect.DeepEqual(v, test.aliases) {
+				t.Errorf("expected %v, got: %v", test.aliases, v)
+			}
+		})
+	}
+	for range 10 {
+		r := NewRegistry()
+		for _, c := range []Color{New(0x80, 0x80, 0x80, 0xff), r.New(0x80, 0x80, 0x80, 0xff)} {
+			if c.Name() != "gray" || c.AsText() != "gray" {
+				t.Fatalf("expected gray, got: %q", c.Name())
+			}
+		}
+		if c := r.New(0, 0xff, 0xff, 0xff); c.Name() != "aqua" {
+			t.Fatalf("expected aqua, got: %q", c.Name())
+		}
+	}
+}
+
+func TestRegistryPrefer(t *testing.T) {
+	r := NewRegistry()
+	r.SetSpelling(SpellingBritish)
+	r.Prefer(Cyan, "unknown")
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"#808080", "grey"},
+		{"#2f4f4f", "darkslategrey"},
+		{"#00ffff", "cyan"},
+		{"#ff00ff", "fuchsia"},
+		{"gray", "gray"},
+	}
+	for _, test := range tests {
+		c, err := Parse(test.s, WithRegistry(r))
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		if c.Name() != test.exp {
+			t.Errorf("%s: expected %s, got: %s", test.s, test.exp, c.Name())
+		}
+	}
+	// preferred names remain canonical
+	r.RegisterName("neutral-500", Grey)
+	if v := r.Canonical("neutral-500"); v != Grey {
+		t.Errorf("expected grey, got: %q", v)
+	}
+	r.Unregister("grey")
+	if v := r.Canonical("gray"); v != Gray {
+		t.Errorf("expected gray, got: %q", v)
+	}
+	r.RegisterName("grey", Grey)
+	if v := r.Canonical("gray"); v != Grey {
+		t.Errorf("expected grey, got: %q", v)
+	}
+	r.SetSpelling(SpellingAmerican)
+	if v := r.Canonical("grey"); v != Gray {
+		t.Errorf("expected gray, got: %q", v)
+	}
+	if c := New(0x80, 0x80, 0x80, 0xff); c.Name() != "gray" {
+		t.Errorf("expected gray, got: %q", c.Name())
+	}
+	// the last registered name is canonical, unless another is preferred
+	r.RegisterName("red1", Red)
+	if v := r.Canonical("red"); v != "red1" {
+		t.Errorf("expected red1, got: %q", v)
+	}
+	r.Prefer(Red)
+	r.RegisterName("x11red", Red)
+	if v := r.Canonical("red1"); v != Red {
+		t.Errorf("expected red, got: %q", v)
+	}
+}
@@ -0,0 +1,340 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FromRelative converts a CSS relative color string to a color, ex: rgb(from
+// red r g b / 50%) or oklch(from #336699 calc(l + 0.1) c h).
+//
+// Supports the rgb, rgba, hsl, hsla, hwb, lab, lch, oklab, oklch, and color
+// functions. The origin color may be any color supported by [Parse], and is
+// converted to the function's color space, where each of its channels are
+// available as keywords named after the channel (ex: r, g, b, and alpha for
+// rgb). Channels may be keywords, numbers, percentages, the none keyword, or
+// a calc() expression using +, -, *, /, and parentheses. Colors outside of
+// the sRGB gamut are gamut mapped. See [FloatColor.Color].
+//
+// var() references resolve to their fallback, if any. Use [Parse] with
+// [WithVarResolver] to resolve custom properties.
+//
+// See: https://www.w3.org/TR/css-color-5/#relative-colors
+func FromRelative(s string) (Color, bool) {
+	c, err := options{}.parseRelative(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// parseRelative parses a CSS relative color string. Other color functions
+// are rejected with errNoMatch.
+func (o options) parseRelative(s string) (FloatColor, *ParseError) {
+	// check for the from keyword before parsing the arguments
+	name := funcName(s)
+	if name == "" {
+		return FloatColor{}, errNoMatch
+	}
+	i := len(name) + 1
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	if from := s[i:]; len(from) < 5 || !strings.EqualFold(from[:4], "from") || !isSpace(from[4]) {
+		return FloatColor{}, errNoMatch
+	}
+	var space Space
+	scale := 1.0
+	switch name = strings.ToLower(name); name {
+	case "rgb", "rgba":
+		space, scale = SpaceSRGB, 0xff
+	case "hsl", "hsla":
+		space = SpaceHSL
+	case "hwb", "lab", "lch", "oklab", "oklch":
+		space, _ = ParseSpace(name)
+	case "color":
+	default:
+		return FloatColor{}, errNoMatch
+	}
+	invalid := &ParseError{Offset: len(name) + 1, Format: name, Reason: "invalid relative color"}
+	f, err := parseNamedFunc(s, name)
+	switch {
+	case err != nil:
+		return FloatColor{}, err
+	case f.legacy, f.n < 3:
+		return FloatColor{}, invalid
+	}
+	origin, err := o.parseFloat(f.args[1])
+	if err != nil {
+		return FloatColor{}, nested(err, f.offs[1])
+	}
+	args := f.args[2:f.n]
+	if name == "color" {
+		var ok bool
+		if len(args) == 0 {
+			return FloatColor{}, invalid
+		}
+		if space, ok = ParseSpace(args[0]); !ok || space.Polar() != -1 || space == SpaceLab || space == SpaceOKLab {
+			return FloatColor{}, &ParseError{Offset: f.offs[2], Format: name, Reason: fmt.Sprintf("unknown color space %q", args[0])}
+		}
+		args = args[1:]
+	}
+	if len(args) != 3 {
+		return FloatColor{}, invalid
+	}
+	// channel keywords
+	names, refs := relativeChannels(space)
+	v := origin.Convert(space)
+	env := calcEnv{names: [4]string{names[0], names[1], names[2], "alpha"}}
+	for i, x := range v.Channels {
+		env.vals[i] = noneToZero(x) * scale
+	}
+	env.vals[3] = noneToZero(origin.Alpha)
+	// evaluate channels
+	c, h := FloatColor{Space: space}, space.Polar()
+	for i, arg := range args {
+		var ok bool
+		if c.Channels[i], ok = env.eval(arg, refs[i]*scale, i == h); !ok {
+			return FloatColor{}, invalid
+		}
+		c.Channels[i] /= scale
+	}
+	c.Alpha = env.vals[3]
+	if f.alpha != "" {
+		var ok bool
+		if c.Alpha, ok = env.eval(f.alpha, 1, false); !ok {
+			return FloatColor{}, invalid
+		}
+	}
+	c.Alpha = clamp(c.Alpha, 0, 1)
+	// clamp channels as with the absolute color functions
+	switch space {
+	case SpaceSRGB:
+		if scale != 1 {
+			c.Channels = apply(c.Channels, func(x float64) float64 { return clamp(x, 0, 1) })
+		}
+	case SpaceHSL, SpaceHWB:
+		c.Channels[1], c.Channels[2] = clamp(c.Channels[1], 0, 100), clamp(c.Channels[2], 0, 100)
+	case SpaceLab, SpaceLCh, SpaceOKLab, SpaceOKLCH:
+		c.Channels[0] = clamp(c.Channels[0], 0, refs[0])
+		if h != -1 {
+			c.Channels[1] = max(c.Channels[1], 0)
+		}
+	}
+	return c, nil
+}
+
+// relativeChannels returns the channel keywords for the color space, and the
+// value of 100% for each channel. Hues do not allow percentages, and have a
+// value of 0.
+func relativeChannels(space Space) ([3]string, [3]float64) {
+	switch space {
+	case SpaceHSL:
+		return [3]string{"h", "s", "l"}, [3]float64{0, 100, 100}
+	case SpaceHWB:
+		return [3]string{"h", "w", "b"}, [3]float64{0, 100, 100}
+	case SpaceLab:
+		return [3]string{"l", "a", "b"}, [3]float64{100, 125, 125}
+	case SpaceLCh:
+		return [3]string{"l", "c", "h"}, [3]float64{100, 150, 0}
+	case SpaceOKLab:
+		return [3]string{"l", "a", "b"}, [3]float64{1, 0.4, 0.4}
+	case SpaceOKLCH:
+		return [3]string{"l", "c", "h"}, [3]float64{1, 0.4, 0}
+	case SpaceXYZD50, SpaceXYZD65:
+		return [3]string{"x", "y", "z"}, [3]float64{1, 1, 1}
+	}
+	return [3]string{"r", "g", "b"}, [3]float64{1, 1, 1}
+}
+
+// calcEnv is the environment for evaluating relative color channels, holding
+// the channel keywords and their values.
+type calcEnv struct {
+	names [4]string
+	vals  [4]float64
+}
+
+// eval evaluates a relative color channel in s, which may be a channel
+// keyword, a number, a percentage, where 100% is ref, the none keyword, or a
+// calc() expression. Angles are allowed when angle is true, and are returned
+// in degrees.
+func (env calcEnv) eval(s string, ref float64, angle bool) (float64, bool) {
+	if strings.EqualFold(s, "none") {
+		return math.NaN(), true
+	}
+	p := calcParser{s: s, env: env, ref: ref, angle: angle}
+	v, ok := p.primary()
+	if !ok || p.i != len(s) || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+// calcParser is a recursive descent parser for calc() expressions.
+type calcParser struct {
+	s     string
+	i     int
+	env   calcEnv
+	ref   float64
+	angle bool
+}
+
+// expr parses a sum, ex: l + 0.1.
+func (p *calcParser) expr() (float64, bool) {
+	v, ok := p.term()
+	for ok {
+		switch p.skip(); p.peek() {
+		case '+':
+			var x float64
+			p.i++
+			x, ok = p.term()
+			v += x
+		case '-':
+			var x float64
+			p.i++
+			x, ok = p.term()
+			v -= x
+		default:
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// term parses a product, ex: c * 2.
+func (p *calcParser) term() (float64, bool) {
+	p.skip()
+	v, ok := p.primary()
+	for ok {
+		switch p.skip(); p.peek() {
+		case '*':
+			var x float64
+			p.i++
+			p.skip()
+			x, ok = p.primary()
+			v *= x
+		case '/':
+			var x float64
+			p.i++
+			p.skip()
+			x, ok = p.primary()
+			v /= x
+		default:
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// primary parses a keyword, a number (with an optional unit), a
+// parenthesized expression, or a calc() expression.
+func (p *calcParser) primary() (float64, bool) {
+	s := p.s[p.i:]
+	switch {
+	case s == "":
+		return 0, false
+	case s[0] == '(':
+		p.i++
+		return p.group()
+	case len(s) > 4 && strings.EqualFold(s[:5], "calc("):
+		p.i += 5
+		return p.group()
+	case numberLen(s) != 0:
+		n := numberLen(s)
+		j := n
+		for j < len(s) && (s[j] == '%' || isLetter(s[j])) {
+			j++
+		}
+		p.i += j
+		v, unit, ok := parseValue(s[:j])
+		switch {
+		case !ok:
+			return 0, false
+		case unit == "":
+			return v, true
+		case unit == "%" && p.ref != 0:
+			return v / 100 * p.ref, true
+		case p.angle:
+			return parseHue(v, unit)
+		}
+		return 0, false
+	case s[0] == '-' || s[0] == '+':
+		p.i++
+		v, ok := p.primary()
+		if s[0] == '-' {
+			v = -v
+		}
+		return v, ok
+	}
+	j := 0
+	for j < len(s) && isLetter(s[j]) {
+		j++
+	}
+	for i, name := range p.env.names {
+		if j != 0 && strings.EqualFold(s[:j], name) {
+			p.i += j
+			return p.env.vals[i], true
+		}
+	}
+	return 0, false
+}
+
+// group parses the remainder of a parenthesized expression.
+func (p *calcParser) group() (float64, bool) {
+	v, ok := p.expr()
+	if p.skip(); !ok || p.peek() != ')' {
+		return 0, false
+	}
+	p.i++
+	return v, true
+}
+
+// skip skips whitespace.
+func (p *calcParser) skip() {
+	for p.i < len(p.s) && isSpace(p.s[p.i]) {
+		p.i++
+	}
+}
+
+// peek returns the next byte, or 0 when there are no more bytes.
+func (p *calcParser) peek() byte {
+	if p.i < len(p.s) {
+		return p.s[p.i]
+	}
+	return 0
+}
+
+// parseVar parses a CSS var() reference, ex: var(--brand) or var(--brand,
+// red), resolving the custom property with the options' variable resolver.
+// The fallback is used when the custom property cannot be resolved.
+func (o options) parseVar(s string) (FloatColor, *ParseError) {
+	args, off, err := funcArgs(s, "var")
+	if err != nil {
+		return FloatColor{}, err
+	}
+	name, fallback, hasFallback := strings.Cut(args, ",")
+	off += len(name) - len(strings.TrimLeft(name, " \t\n\r\f"))
+	if name = strings.TrimSpace(name); len(name) < 3 || !strings.HasPrefix(name, "--") {
+		return FloatColor{}, errorf(off, "invalid custom property name %q", name)
+	}
+	if o.resolve != nil {
+		if v, ok := o.resolve(name); ok {
+			c, err := o.parseFloat(v)
+			if err != nil {
+				return FloatColor{}, errorf(off, "custom property %s has invalid value %q", name, v)
+			}
+			return c, nil
+		}
+	}
+	if !hasFallback {
+		return FloatColor{}, errorf(off, "undefined custom property %s", name)
+	}
+	c, err := o.parseFloat(fallback)
+	return c, nested(err, len(s)-1-len(fallback))
+}
+
+// isLetter returns true when c is an ASCII letter.
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,125 @@
+package colors
+
+import (
+	"image/color"
+	"strconv"
+	"testing"
+)
+
+func TestParseRelative(t *testing.T) {
+	vars := map[string]string{
+		"--brand":  "#336699",
+		"--Accent": "rgb(from var(--brand) b g r)",
+		"--a":      "var(--b)",
+		"--b":      "var(--a)",
+	}
+	resolve := func(name string) (string, bool) {
+		s, ok := vars[name]
+		return s, ok
+	}
+	tests := []struct {
+		s   string
+		exp color.NRGBA
+	}{
+		{"rgb(from red r g b)", color.NRGBA{255, 0, 0, 255}},
+		{"rgba(from red r g b / 50%)", color.NRGBA{255, 0, 0, 128}},
+		{"RGB(FROM RED R G B / 0.5)", color.NRGBA{255, 0, 0, 128}},
+		{"rgb(from #336699 b g r)", color.NRGBA{153, 102, 51, 255}},
+		{"rgb(from rgb(10 20 30) calc(r * 2) calc(g + 10) calc(b - 40))", color.NRGBA{20, 30, 0, 255}},
+		{"rgb(from rgb(10 20 30) calc((r + g) * 2) calc(-1 * -g) calc(b/3))", color.NRGBA{60, 20, 10, 255}},
+		{"rgb(from rgb(10 20 30) calc(r+g) calc(g -5) calc(calc(b) - (r - 5)))", color.NRGBA{30, 15, 25, 255}},
+		{"rgb(from red r g b / calc(alpha / 2))", color.NRGBA{255, 0, 0, 128}},
+		{"rgb(from rgb(255 0 0 / 50%) r g b)", color.NRGBA{255, 0, 0, 128}},
+		{"rgb(from red 50% g b)", color.NRGBA{128, 0, 0, 255}},
+		{"rgb(from red calc(50% + 10) g b)", color.NRGBA{138, 0, 0, 255}},
+		{"rgb(from red none g b)", color.NRGBA{0, 0, 0, 255}},
+		{"rgb(from red 300 -10 b)", color.NRGBA{255, 0, 0, 255}},
+		{"rgb(from rgb(from red r 255 b) r g b)", color.NRGBA{255, 255, 0, 255}},
+		{"hsl(from red calc(h + 120) s l)", color.NRGBA{0, 255, 0, 255}},
+		{"hsla(from red calc(h + 0.5turn) s l)", color.NRGBA{0, 255, 255, 255}},
+		{"hsl(from red h s calc(l / 2))", color.NRGBA{127, 0, 0, 255}},
+		{"hsl(from red h 0% l)", color.NRGBA{127, 127, 127, 255}},
+		{"hwb(from blue h w b)", color.NRGBA{0, 0, 255, 255}},
+		{"hwb(from blue h 100 b)", color.NRGBA{255, 255, 255, 255}},
+		{"lab(from white l 0 0)", color.NRGBA{255, 255, 255, 255}},
+		{"lab(from white calc(l / 2) a b)", color.NRGBA{119, 119, 119, 255}},
+		{"lch(from #336699 l c h)", color.NRGBA{51, 102, 153, 255}},
+		{"oklab(from #336699 l a b / 0.25)", color.NRGBA{51, 102, 153, 64}},
+		{"oklch(from #336699 l c h)", color.NRGBA{51, 102, 153, 255}},
+		{"oklch(from #336699 calc(l + 0.1) c h)", color.NRGBA{80, 132, 185, 255}},
+		{"oklch(from #336699 l 0 h)", color.NRGBA{99, 99, 99, 255}},
+		{"oklch(from black 200% c h)", color.NRGBA{255, 255, 255, 255}},
+		{"color(from red srgb r g b)", color.NRGBA{255, 0, 0, 255}},
+		{"color(from red srgb calc(r / 2) g b)", color.NRGBA{128, 0, 0, 255}},
+		{"color(from red display-p3 r g b)", color.NRGBA{255, 0, 0, 255}},
+		{"color(from red xyz x y z)", color.NRGBA{255, 0, 0, 255}},
+		{"color(from red xyz-d50 calc(x * 0) y z)", color.NRGBA{0, 142, 86, 255}},
+		{"rgb(from var(--brand) r g b / 50%)", color.NRGBA{51, 102, 153, 128}},
+		{"var(--brand)", color.NRGBA{51, 102, 153, 255}},
+		{"var(--Accent)", color.NRGBA{153, 102, 51, 255}},
+		{"var(--missing, red)", color.NRGBA{255, 0, 0, 255}},
+		{"var(--missing, rgb(0, 0, 255))", color.NRGBA{0, 0, 255, 255}},
+		{"var(--missing, var(--brand))", color.NRGBA{51, 102, 153, 255}},
+		{"color-mix(in srgb, var(--brand), var(--Accent))", color.NRGBA{102, 102, 102, 255}},
+		{"rgb(from color-mix(in srgb, red, blue) r g b)", color.NRGBA{128, 0, 128, 255}},
+		{"var(--accent)", color.NRGBA{}},
+		{"var(--missing)", color.NRGBA{}},
+		{"var(--a)", color.NRGBA{}},
+		{"var(brand)", color.NRGBA{}},
+		{"var(--)", color.NRGBA{}},
+		{"rgb(from red r g)", color.NRGBA{}},
+		{"rgb(from red r g b alpha)", color.NRGBA{}},
+		{"rgb(from red, r, g, b)", color.NRGBA{}},
+		{"rgb(from red x g b)", color.NRGBA{}},
+		{"rgb(from red r+1 g b)", color.NRGBA{}},
+		{"rgb(from red calc(r + ) g b)", color.NRGBA{}},
+		{"rgb(from red calc(r g b)", color.NRGBA{}},
+		{"rgb(from red calc(r g) g b)", color.NRGBA{}},
+		{"rgb(from red calc(r / 0) g b)", color.NRGBA{}},
+		{"rgb(from red 10deg g b)", color.NRGBA{}},
+		{"rgb(from unknown r g b)", color.NRGBA{}},
+		{"rgb(from r g b)", color.NRGBA{}},
+		{"hsl(from red 10% s l)", color.NRGBA{}},
+		{"hsl(from red h s)", color.NRGBA{}},
+		{"hwb(from red h s l)", color.NRGBA{}},
+		{"color(from red lab l a b)", color.NRGBA{}},
+		{"color(from red unknown r g b)", color.NRGBA{}},
+		{"color(from red srgb)", color.NRGBA{}},
+		{"color(from red)", color.NRGBA{}},
+		{"foo(from red r g b)", color.NRGBA{}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			c, err := Parse(test.s, WithVarResolver(resolve))
+			switch {
+			case test.exp == (color.NRGBA{}) && err == nil:
+				t.Fatalf("expected error, got: %v", c)
+			case test.exp == (color.NRGBA{}):
+			case err != nil:
+				t.Fatalf("expected no error, got: %v", err)
+			case !c.Is(test.exp):
+				t.Errorf("expected %v, got: %#v", test.exp, c)
+			}
+		})
+	}
+}
+
+func TestFromRelative(t *testing.T) {
+	if c, ok := FromRelative("hsl(from red calc(h + 240) s l)"); !ok || !c.Is(Blue) {
+		t.Errorf("expected blue, got: %v %t", c, ok)
+	}
+	if c, ok := FromRelative("rgb(from var(--brand) r g b)"); ok {
+		t.Errorf("expected no var resolution, got: %v", c)
+	}
+	if c, ok := FromRelative("rgb(from var(--brand, red) r g b)"); !ok || !c.Is(Red) {
+		t.Errorf("expected red, got: %v %t", c, ok)
+	}
+	c, err := ParseFloatColor("oklch(from color(display-p3 1 0 0) l c calc(h + 360))")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	v := NewFloat(SpaceDisplayP3, 1, 0, 0, 1).Convert(SpaceOKLCH)
+	if c.Space != SpaceOKLCH || !near(0.000001, c.Channels[0], v.Channels[0], c.Channels[1], v.Channels[1], c.Channels[2], v.Channels[2]+360) {
+		t.Errorf("expected %s, got: %s", v, c)
+	}
+}
@@ -0,0 +1,238 @@
+package colors
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Match is a color found in text.
+type Match struct {
+	// Start and End are the byte offsets of the color in the text.
+	Start, End int
+	// Text is the color's text, ex: #ffe4e1 or rgb(255 228 225).
+	Text string
+	// Color is the parsed color.
+	Color Color
+}
+
+// Scan finds the colors in text, such as in a CSS, Markdown, or Go source
+// file, returning the colors and their byte offsets. See [Parser.Scan].
+func Scan(text string, opts ...Option) []Match {
+	return DefaultParser.With(opts...).Scan(text)
+}
+
+// Replace replaces the colors in text with the result of f. See
+// [Parser.Replace].
+func Replace(text string, f func(Match) string, opts ...Option) string {
+	return DefaultParser.With(opts...).Replace(text, f)
+}
+
+// NewScanner creates a scanner for the colors in r. See [Parser.NewScanner].
+func NewScanner(r io.Reader, opts ...Option) *Scanner {
+	return DefaultParser.With(opts...).NewScanner(r)
+}
+
+// Scan finds the colors in text, returning the colors and their byte
+// offsets.
+//
+// Finds hex literals prefixed with # (ex: #fe1 or #ffe4e1ff), and functional
+// notations (ex: rgb(255 228 225) or color-mix(in srgb, red, blue)) that are
+// valid colors for the parser's formats. Names (ex: mistyrose) are only found
+// when enabled with [WithScanNames].
+//
+// Colors must start and end on a word boundary, such that the red in
+// --brand-red, the #123 in &#123;, and the #abc in #abcdefg are not found.
+// Words are made of letters, digits, underscores, hyphens, and any non-ASCII
+// characters.
+func (p *Parser) Scan(text string) []Match {
+	o := p.opts()
+	var matches []Match
+	for i := 0; i < len(text); {
+		var prev byte
+		if i != 0 {
+			prev = text[i-1]
+		}
+		m, start, _ := o.scan(text[i:], prev, true)
+		if start == -1 {
+			break
+		}
+		m.Start, m.End = m.Start+i, m.End+i
+		matches = append(matches, m)
+		i = m.End
+	}
+	return matches
+}
+
+// Replace replaces the colors in text with the result of f, as found by
+// [Parser.Scan]. Colors are left unchanged when f returns the color's text.
+func (p *Parser) Replace(text string, f func(Match) string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range p.Scan(text) {
+		b.WriteString(text[last:m.Start])
+		b.WriteString(f(m))
+		last = m.End
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// NewScanner creates a scanner for the colors in r, as found by
+// [Parser.Scan].
+func (p *Parser) NewScanner(r io.Reader) *Scanner {
+	s := &Scanner{s: bufio.NewScanner(r), o: *p.opts()}
+	s.s.Split(s.split)
+	return s
+}
+
+// Scanner scans the colors in a [io.Reader], as found by [Parser.Scan].
+// Colors are returned in order, with their byte offsets from the start of the
+// reader. Similar to [bufio.Scanner].
+type Scanner struct {
+	s *bufio.Scanner
+	o options
+	// off is the offset of the scanner's unread data, and prev is the byte
+	// preceding it.
+	off  int
+	prev byte
+	m    Match
+}
+
+// Scan advances the scanner to the next color, returning false when there
+// are no more colors or an error occurred.
+func (s *Scanner) Scan() bool {
+	return s.s.Scan()
+}
+
+// Match returns the most recent color found by [Scanner.Scan].
+func (s *Scanner) Match() Match {
+	return s.m
+}
+
+// Err returns the first error that occurred reading from the reader, if any.
+func (s *Scanner) Err() error {
+	return s.s.Err()
+}
+
+// Buffer sets the initial buffer and the maximum buffer size, as with
+// [bufio.Scanner.Buffer]. The maximum buffer size limits the length of a
+// functional notation that can be found.
+func (s *Scanner) Buffer(buf []byte, max int) {
+	s.s.Buffer(buf, max)
+}
+
+// split is the [bufio.SplitFunc] for the scanner.
+func (s *Scanner) split(data []byte, atEOF bool) (int, []byte, error) {
+	m, start, more := s.o.scan(s.o.bytesString(data), s.prev, atEOF)
+	n := m.End
+	switch {
+	case more:
+		// a color may start at start, but extend past the end of data
+		n = start
+	case start == -1:
+		n = len(data)
+	}
+	if n != 0 {
+		s.off, s.prev = s.off+n, data[n-1]
+	}
+	if more || start == -1 {
+		return n, nil, nil
+	}
+	m.Start, m.End, m.Text = m.Start+s.off-n, s.off, strings.Clone(m.Text)
+	s.m = m
+	return n, data[start:n], nil
+}
+
+// maxScanFuncLen is the maximum length of a functional notation found by
+// the scanner.
+const maxScanFuncLen = 1024
+
+// maxScanWordLen is the maximum length of a word that can be a color, such
+// as a hex literal, name, or function name.
+const maxScanWordLen = 64
+
+// scan finds the first color in s, where prev is the byte preceding s, or 0.
+// Returns the match and its start, or -1 when there is no color in s. When
+// atEOF is false and a color may start at start but extend past the end of
+// s, more is returned as true.
+func (o options) scan(s string, prev byte, atEOF bool) (Match, int, bool) {
+	for i := 0; i < len(s); {
+		if i != 0 {
+			prev = s[i-1]
+		}
+		if isWordByte(prev) || (s[i] == '#' && prev == '&') {
+			i++
+			continue
+		}
+		// end of the word at i
+		j := i + 1
+		for j < len(s) && isWordByte(s[j]) {
+			j++
+		}
+		if j == len(s) && !atEOF && j-i < maxScanWordLen {
+			return Match{}, i, true
+		}
+		switch c := s[i]; {
+		case c == '#':
+			if n := j - i - 1; n == 3 || n == 4 || n == 6 || n == 8 {
+				if v, err := o.parse(s[i:j]); err == nil {
+					return Match{Start: i, End: j, Text: s[i:j], Color: v}, i, false
+				}
+			}
+		case !isLetter(c):
+		case j < len(s) && s[j] == '(':
+			k, ok := closeParen(s, j, maxScanFuncLen)
+			if !ok && k == len(s) && !atEOF && k-i < maxScanFuncLen {
+				return Match{}, i, true
+			}
+			if ok {
+				if v, err := o.parse(s[i : k+1]); err == nil {
+					return Match{Start: i, End: k + 1, Text: s[i : k+1], Color: v}, i, false
+				}
+			}
+		case o.scanNames:
+			if f, ok := o.enabledFormat("name"); ok {
+				if v, err := f.color(o, s[i:j]); err == nil {
+					return Match{Start: i, End: j, Text: s[i:j], Color: v}, i, false
+				}
+			}
+		}
+		if !isWordByte(s[i]) {
+			i++
+		} else {
+			i = j
+		}
+	}
+	return Match{}, -1, false
+}
+
+// closeParen returns the index of the closing parenthesis matching the
+// opening parenthesis at i, looking at most max bytes past i. When there is
+// no closing parenthesis, returns the index at which the search stopped.
+func closeParen(s string, i, max int) (int, bool) {
+	depth := 0
+	for j := i; j < len(s) && j-i < max; j++ {
+		switch s[j] {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return j, true
+			}
+		case ';', '{', '}':
+			// not part of a color
+			return j, false
+		}
+	}
+	return min(len(s), i+max), false
+}
+
+// isWordByte returns true when c is part of a word: a letter, a digit, an
+// underscore, a hyphen, or part of a non-ASCII character.
+func isWordByte(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_' || c == '-' || c >= 0x80
+}
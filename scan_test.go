@@ -0,0 +1,132 @@
+package colors
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		s    string
+		opts []Option
+		exp  []string
+	}{
+		{"", nil, nil},
+		{"no colors here", nil, nil},
+		{"a { color: #fe1; background: rgb(255 0 0 / 50%); }", nil, []string{"#fe1", "rgb(255 0 0 / 50%)"}},
+		{"#ffe4e1,#ffe4e1ff #ffe4e1f #abcdefg", nil, []string{"#ffe4e1", "#ffe4e1ff"}},
+		{"border: 1px solid hsl(6deg, 100%, 94.12%)", nil, []string{"hsl(6deg, 100%, 94.12%)"}},
+		{"color-mix(in srgb, red, blue) color-mix(in srgb, red)", nil, []string{"color-mix(in srgb, red, blue)"}},
+		{"oklch(94% 0.03 25) lab(92.76 9.2 5.03)\ncolor(srgb 1 0.89 0.88)", nil, []string{"oklch(94% 0.03 25)", "lab(92.76 9.2 5.03)", "color(srgb 1 0.89 0.88)"}},
+		{"rgb(\n  255\n  0\n  0\n)", nil, []string{"rgb(\n  255\n  0\n  0\n)"}},
+		{"rgb(x, y, z) myrgb(0 0 0) rgb (0 0 0) 3rgb(0 0 0)", nil, nil},
+		{"&#123; x#fff #fff_ #fff-a a-#fff", nil, nil},
+		{"## Heading #add", nil, []string{"#add"}},
+		{"red, --brand-red, misty-rose, MistyRose", nil, nil},
+		{"red, --brand-red, misty-rose, MistyRose", []Option{WithScanNames(true)}, []string{"red", "misty-rose", "MistyRose"}},
+		{"the reds and redder rgb(0 0 0)", []Option{WithScanNames(true)}, []string{"rgb(0 0 0)"}},
+		{"a facade of deadbeef ffe4e1", []Option{WithScanNames(true)}, nil},
+		{"c := colors.MustParse(\"#ff0000\") // red", nil, []string{"#ff0000"}},
+		{"#ff0000 rgb(0 0 0)", []Option{WithFormats("rgb")}, []string{"rgb(0 0 0)"}},
+		{"rgb(0 0 0", nil, nil},
+		{"rgb(0 0 0 { rgb(1 1 1)", nil, []string{"rgb(1 1 1)"}},
+		{"über#fff é rgb(0 0 0)", nil, []string{"rgb(0 0 0)"}},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			var v []string
+			for _, m := range Scan(test.s, test.opts...) {
+				if s := test.s[m.Start:m.End]; s != m.Text {
+					t.Errorf("expected text %q to be %q", m.Text, s)
+				}
+				c, err := Parse(m.Text, test.opts...)
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				if c != m.Color {
+					t.Errorf("expected %#v, got: %#v", c, m.Color)
+				}
+				v = append(v, m.Text)
+			}
+			if !reflect.DeepEqual(v, test.exp) {
+				t.Errorf("expected %q, got: %q", test.exp, v)
+			}
+		})
+	}
+}
+
+func TestScanOffsets(t *testing.T) {
+	s := "p { color: #f00; }\nq { color: rgb(0 255 0); }"
+	exp := []Match{
+		{Start: 11, End: 15, Text: "#f00", Color: Red.Color()},
+		{Start: 30, End: 42, Text: "rgb(0 255 0)", Color: Lime.Color()},
+	}
+	if v := Scan(s); !reflect.DeepEqual(v, exp) {
+		t.Errorf("expected %v, got: %v", exp, v)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	tests := []struct {
+		s   string
+		f   func(Match) string
+		exp string
+	}{
+		{"a { color: #f00; }", func(m Match) string { return m.Color.AsRGB() }, "a { color: rgb(255,0,0); }"},
+		{"#f00 #0f0 #00f", func(m Match) string { return m.Color.Name() }, "red lime blue"},
+		{"#f00 rgb(0 0 0)", func(m Match) string { return m.Text }, "#f00 rgb(0 0 0)"},
+		{"no colors", func(m Match) string { return "x" }, "no colors"},
+		{"", func(m Match) string { return "x" }, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			if v := Replace(test.s, test.f); v != test.exp {
+				t.Errorf("expected %q, got: %q", test.exp, v)
+			}
+		})
+	}
+}
+
+func TestScanner(t *testing.T) {
+	var b strings.Builder
+	for i := range 500 {
+		switch i % 5 {
+		case 0:
+			b.WriteString(".a { color: #ffe4e1; }\n")
+		case 1:
+			b.WriteString("  background: color-mix(in oklch, red 40%, rgb(0 0 255));\n")
+		case 2:
+			b.WriteString("  --brand-red: &#123; #abcdefg\n")
+		case 3:
+			b.WriteString("Misty_Rose ")
+		case 4:
+			b.WriteString(strings.Repeat("x", 100) + " oklch(70% 0.1 200)")
+		}
+	}
+	s := b.String()
+	for _, opts := range [][]Option{nil, {WithScanNames(true)}} {
+		exp := Scan(s, opts...)
+		if len(exp) == 0 {
+			t.Fatalf("expected matches")
+		}
+		for _, r := range []func() *Scanner{
+			func() *Scanner { return NewScanner(strings.NewReader(s), opts...) },
+			func() *Scanner { return NewScanner(iotest.OneByteReader(strings.NewReader(s)), opts...) },
+			func() *Scanner { return NewScanner(iotest.HalfReader(strings.NewReader(s)), opts...) },
+		} {
+			sc := r()
+			var v []Match
+			for sc.Scan() {
+				v = append(v, sc.Match())
+			}
+			if err := sc.Err(); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if !reflect.DeepEqual(v, exp) {
+				t.Errorf("expected %d matches, got: %d", len(exp), len(v))
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package colors
+
+import (
+	"math"
+	"strings"
+)
+
+// Space is a color space.
+type Space string
+
+// Color spaces.
+//
+// The channels for each space, in the ranges used by CSS, are:
+//
+//	SpaceSRGB, SpaceSRGBLinear, SpaceDisplayP3, SpaceA98RGB,
+//	SpaceProPhotoRGB, SpaceRec2020 - r, g, b (0-1)
+//	SpaceXYZD50, SpaceXYZD65       - x, y, z (0-1)
+//	SpaceHSL                       - h (degrees), s, l (0-100)
+//	SpaceHWB                       - h (degrees), w, b (0-100)
+//	SpaceLab                       - l (0-100), a, b
+//	SpaceLCh                       - l (0-100), c, h (degrees)
+//	SpaceOKLab                     - l (0-1), a, b
+//	SpaceOKLCH                     - l (0-1), c, h (degrees)
+const (
+	SpaceSRGB        Space = "srgb"
+	SpaceSRGBLinear  Space = "srgb-linear"
+	SpaceDisplayP3   Space = "display-p3"
+	SpaceA98RGB      Space = "a98-rgb"
+	SpaceProPhotoRGB Space = "prophoto-rgb"
+	SpaceRec2020     Space = "rec2020"
+	SpaceXYZD50      Space = "xyz-d50"
+	SpaceXYZD65      Space = "xyz-d65"
+	SpaceHSL         Space = "hsl"
+	SpaceHWB         Space = "hwb"
+	SpaceLab         Space = "lab"
+	SpaceLCh         Space = "lch"
+	SpaceOKLab       Space = "oklab"
+	SpaceOKLCH       Space = "oklch"
+)
+
+// ParseSpace parses a CSS color space name, ex: display-p3. The xyz name is
+// an alias for xyz-d65.
+func ParseSpace(s string) (Space, bool) {
+	for _, space := range spaces {
+		if strings.EqualFold(s, string(space)) {
+			return space, true
+		}
+	}
+	if strings.EqualFold(s, "xyz") {
+		return SpaceXYZD65, true
+	}
+	return "", false
+}
+
+// Bounded returns true when the color space has gamut limits, ie, when it is
+// a RGB color space.
+func (space Space) Bounded() bool {
+	switch space {
+	case SpaceSRGB, SpaceSRGBLinear, SpaceDisplayP3, SpaceA98RGB, SpaceProPhotoRGB, SpaceRec2020, SpaceHSL, SpaceHWB:
+		return true
+	}
+	return false
+}
+
+// Polar returns the index of the hue channel for a polar (cylindrical) color
+// space, or -1 when the color space is not polar.
+func (space Space) Polar() int {
+	switch space {
+	case SpaceHSL, SpaceHWB:
+		return 0
+	case SpaceLCh, SpaceOKLCH:
+		return 2
+	}
+	return -1
+}
+
+// spaces are the known color spaces.
+var spaces = []Space{
+	SpaceSRGB,
+	SpaceSRGBLinear,
+	SpaceDisplayP3,
+	SpaceA98RGB,
+	SpaceProPhotoRGB,
+	SpaceRec2020,
+	SpaceXYZD50,
+	SpaceXYZD65,
+	SpaceHSL,
+	SpaceHWB,
+	SpaceLab,
+	SpaceLCh,
+	SpaceOKLab,
+	SpaceOKLCH,
+}
+
+// toXYZ65 converts channels in the color space to CIE XYZ (D65). Unknown
+// color spaces are treated as sRGB.
+func toXYZ65(space Space, v [3]float64) [3]float64 {
+	switch space {
+	case SpaceSRGBLinear:
+		return linearSRGBToXYZ65.mul(v)
+	case SpaceDisplayP3:
+		return linearP3ToXYZ65.mul(apply(v, srgbToLinear))
+	case SpaceA98RGB:
+		return linearA98ToXYZ65.mul(apply(v, a98ToLinear))
+	case SpaceProPhotoRGB:
+		return xyz50ToXYZ65.mul(linearProPhotoToXYZ50.mul(apply(v, proPhotoToLinear)))
+	case SpaceRec2020:
+		return linearRec2020ToXYZ65.mul(apply(v, rec2020ToLinear))
+	case SpaceXYZD50:
+		return xyz50ToXYZ65.mul(v)
+	case SpaceXYZD65:
+		return v
+	case SpaceHSL:
+		r, g, b := hslToRGB(v[0], v[1]/100, v[2]/100)
+		return srgbToXYZ65(r, g, b)
+	case SpaceHWB:
+		r, g, b := hwbToRGB(v[0], v[1]/100, v[2]/100)
+		return srgbToXYZ65(r, g, b)
+	case SpaceLab:
+		return xyz50ToXYZ65.mul(labToXYZ(v))
+	case SpaceLCh:
+		l, a, b := fromPolar(v[0], v[1], v[2])
+		return xyz50ToXYZ65.mul(labToXYZ([3]float64{l, a, b}))
+	case SpaceOKLab:
+		return oklabToXYZ(v)
+	case SpaceOKLCH:
+		l, a, b := fromPolar(v[0], v[1], v[2])
+		return oklabToXYZ([3]float64{l, a, b})
+	}
+	return srgbToXYZ65(v[0], v[1], v[2])
+}
+
+// fromXYZ65 converts CIE XYZ (D65) to channels in the color space. Unknown
+// color spaces are treated as sRGB.
+func fromXYZ65(space Space, v [3]float64) [3]float64 {
+	switch space {
+	case SpaceSRGBLinear:
+		return xyz65ToLinearSRGB.mul(v)
+	case SpaceDisplayP3:
+		return apply(xyz65ToLinearP3.mul(v), linearToSRGB)
+	case SpaceA98RGB:
+		return apply(xyz65ToLinearA98.mul(v), linearToA98)
+	case SpaceProPhotoRGB:
+		return apply(xyz50ToLinearProPhoto.mul(xyz65ToXYZ50.mul(v)), linearToProPhoto)
+	case SpaceRec2020:
+		return apply(xyz65ToLinearRec2020.mul(v), linearToRec2020)
+	case SpaceXYZD50:
+		return xyz65ToXYZ50.mul(v)
+	case SpaceXYZD65:
+		return v
+	case SpaceHSL:
+		h, s, l := rgbToHSL(xyz65ToSRGB(v))
+		return [3]float64{h, s * 100, l * 100}
+	case SpaceHWB:
+		r, g, b := xyz65ToSRGB(v)
+		h, _, _ := rgbToHSL(r, g, b)
+		return [3]float64{h, min(r, g, b) * 100, (1 - max(r, g, b)) * 100}
+	case SpaceLab:
+		return xyzToLab(xyz65ToXYZ50.mul(v))
+	case SpaceLCh:
+		lab := xyzToLab(xyz65ToXYZ50.mul(v))
+		l, c, h := toPolar(lab[0], lab[1], lab[2])
+		return [3]float64{l, c, h}
+	case SpaceOKLab:
+		return xyzToOKLab(v)
+	case SpaceOKLCH:
+		lab := xyzToOKLab(v)
+		l, c, h := toPolar(lab[0], lab[1], lab[2])
+		return [3]float64{l, c, h}
+	}
+	r, g, b := xyz65ToSRGB(v)
+	return [3]float64{r, g, b}
+}
+
+// apply applies f to each of the channels in v.
+func apply(v [3]float64, f func(float64) float64) [3]float64 {
+	return [3]float64{f(v[0]), f(v[1]), f(v[2])}
+}
+
+// a98ToLinear converts a gamma encoded a98-rgb channel to linear light.
+func a98ToLinear(v float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), 563.0/256), v)
+}
+
+// linearToA98 converts a linear light channel to gamma encoded a98-rgb.
+func linearToA98(v float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), 256.0/563), v)
+}
+
+// proPhotoToLinear converts a gamma encoded prophoto-rgb channel to linear
+// light.
+func proPhotoToLinear(v float64) float64 {
+	if a := math.Abs(v); a > 16.0/512 {
+		return math.Copysign(math.Pow(a, 1.8), v)
+	}
+	return v / 16
+}
+
+// linearToProPhoto converts a linear light channel to gamma encoded
+// prophoto-rgb.
+func linearToProPhoto(v float64) float64 {
+	if a := math.Abs(v); a >= 1.0/512 {
+		return math.Copysign(math.Pow(a, 1/1.8), v)
+	}
+	return 16 * v
+}
+
+// rec2020ToLinear converts a gamma encoded rec2020 channel to linear light.
+func rec2020ToLinear(v float64) float64 {
+	if a := math.Abs(v); a >= rec2020Beta*4.5 {
+		return math.Copysign(math.Pow((a+rec2020Alpha-1)/rec2020Alpha, 1/0.45), v)
+	}
+	return v / 4.5
+}
+
+// linearToRec2020 converts a linear light channel to gamma encoded rec2020.
+func linearToRec2020(v float64) float64 {
+	if a := math.Abs(v); a > rec2020Beta {
+		return math.Copysign(rec2020Alpha*math.Pow(a, 0.45)-(rec2020Alpha-1), v)
+	}
+	return 4.5 * v
+}
+
+// rec2020 transfer function constants.
+const (
+	rec2020Alpha = 1.09929682680944
+	rec2020Beta  = 0.018053968510807
+)
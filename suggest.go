@@ -0,0 +1,111 @@
+package colors
+
+import (
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/kenshaw/colors/strcase"
+)
+
+// Suggest returns up to n registered color names similar to s, ordered from
+// most to least similar, for use in "did you mean" messages. Returns all
+// similar names when n <= 0.
+//
+// Names are ranked by their edit distance (the number of single character
+// insertions, deletions, substitutions, and transpositions) to s, after
+// normalizing s as with [FromName], such that Ligth_Blue, ligth-blue, and
+// ligthBlue are equivalent. The words in s are also tried in every order,
+// such that blue light is similar to lightblue. Only names within an edit
+// distance of a third of the length of s (minimum 1) are returned.
+//
+// Names are suggested from the [DefaultRegistry]. See [Registry.Suggest].
+func Suggest(s string, n int) []NamedColor {
+	return DefaultRegistry.Suggest(s, n)
+}
+
+// Suggest returns up to n names from the registry similar to s. See
+// [Suggest].
+func (r *Registry) Suggest(s string, n int) []NamedColor {
+	words := strings.Split(strcase.CamelToSnake(strcase.ForceCamelIdentifier(s)), "_")
+	key := strings.Join(words, "")
+	if key == "" {
+		return nil
+	}
+	// candidate spellings of s, with the words in every order
+	keys := []string{key}
+	if 1 < len(words) && len(words) <= 4 {
+		keys = keys[:0]
+		permute(words, 0, func(v []string) {
+			keys = append(keys, strings.Join(v, ""))
+		})
+	}
+	limit := max(1, len([]rune(key))/3)
+	type match struct {
+		name NamedColor
+		dist int
+	}
+	var matches []match
+	r.each(func(name NamedColor, _ color.NRGBA) {
+		dist := limit + 1
+		for _, k := range keys {
+			dist = min(dist, editDistance(k, string(name)))
+		}
+		if dist <= limit {
+			matches = append(matches, match{name, dist})
+		}
+	})
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].name < matches[j].name
+	})
+	if 0 < n && n < len(matches) {
+		matches = matches[:n]
+	}
+	names := make([]NamedColor, len(matches))
+	for i, m := range matches {
+		names[i] = m.name
+	}
+	return names
+}
+
+// permute calls f with every permutation of v.
+func permute(v []string, i int, f func([]string)) {
+	if i == len(v) {
+		f(v)
+		return
+	}
+	for j := i; j < len(v); j++ {
+		v[i], v[j] = v[j], v[i]
+		permute(v, i+1, f)
+		v[i], v[j] = v[j], v[i]
+	}
+}
+
+// editDistance returns the optimal string alignment distance (the restricted
+// Damerau-Levenshtein distance) between a and b.
+func editDistance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	// rows i-2, i-1, and i
+	prev2, prev, curr := make([]int, len(y)+1), make([]int, len(y)+1), make([]int, len(y)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		curr[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(y)]
+}
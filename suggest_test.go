@@ -0,0 +1,85 @@
+package colors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		s   string
+		n   int
+		exp []NamedColor
+	}{
+		{"ligthblue", 1, []NamedColor{Lightblue}},
+		{"Ligth_Blue", 1, []NamedColor{Lightblue}},
+		{"ligth-blue", 1, []NamedColor{Lightblue}},
+		{"ligthBlue", 1, []NamedColor{Lightblue}},
+		{"fuschia", 0, []NamedColor{Fuchsia}},
+		{"blue light", 1, []NamedColor{Lightblue}},
+		{"gray slate dark", 1, []NamedColor{Darkslategray}},
+		{"rde", 0, []NamedColor{Red}},
+		{"gren", 0, []NamedColor{Green, Grey}},
+		{"mistyrose", 1, []NamedColor{Mistyrose}},
+		{"gray", 0, []NamedColor{Gray, Grey}},
+		{"darkgrey", 0, []NamedColor{Darkgrey, Darkgray, Darkgreen, Darkred}},
+		{"darkgrey", 1, []NamedColor{Darkgrey}},
+		{"", 0, nil},
+		{"xyzzy", 0, nil},
+		{"  ", 0, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			if v := Suggest(test.s, test.n); !reflect.DeepEqual(v, test.exp) && !(len(v) == 0 && len(test.exp) == 0) {
+				t.Errorf("expected %v, got: %v", test.exp, v)
+			}
+		})
+	}
+}
+
+func TestSuggestError(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"fuschia", `invalid color "fuschia" (did you mean "fuchsia"?)`},
+		{" ligth blue ", `invalid color " ligth blue " (did you mean "lightblue"?)`},
+		{"gry", `invalid color "gry" (did you mean "gray" or "grey"?)`},
+		{"xyzzy", `invalid color "xyzzy"`},
+		{"rgb(from fuschia r g b)", `invalid color "rgb(from fuschia r g b)" at offset 9: name: unknown color name "fuschia" (did you mean "fuchsia"?)`},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			var c Color
+			err := c.Pflag().Set(test.s)
+			switch {
+			case err == nil:
+				t.Fatalf("expected error")
+			case err.Error() != test.exp:
+				t.Errorf("expected %q, got: %q", test.exp, err.Error())
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		exp  int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"abc", "acb", 1},
+		{"ca", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"fuschia", "fuchsia", 2},
+		{"ligthblue", "lightblue", 1},
+	}
+	for _, test := range tests {
+		if d := editDistance(test.a, test.b); d != test.exp {
+			t.Errorf("%q %q expected %d, got: %d", test.a, test.b, test.exp, d)
+		}
+	}
+}
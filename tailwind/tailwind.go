@@ -0,0 +1,157 @@
+// Package tailwind provides the Tailwind CSS v3 and v4 default color
+// palettes, for use with the colors package.
+//
+// The palettes are not enabled by default, such that red remains the CSS red.
+// Use [Register] to parse Tailwind colors (ex: slate-900 or sky-500/75) with
+// a [colors.Parser], or [RegisterNames] to register the names for use with
+// [colors.FromName].
+package tailwind
+
+import (
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kenshaw/colors"
+)
+
+// Version is a Tailwind CSS version.
+type Version int
+
+// Versions.
+const (
+	V3 Version = 3
+	V4 Version = 4
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (v Version) String() string {
+	return "v" + strconv.Itoa(int(v))
+}
+
+// Palette returns a copy of the version's default color palette, keyed by
+// name, ex: slate-900. Returns nil for unknown versions.
+func Palette(v Version) map[string]colors.Color {
+	p, ok := palettes[v]
+	if !ok {
+		return nil
+	}
+	m := make(map[string]colors.Color, len(p))
+	for k, c := range p {
+		m[k] = c
+	}
+	return m
+}
+
+// Lookup returns the version's named color, ex: slate-900, with an optional
+// opacity modifier, ex: sky-500/75, sky-500/[.35], or sky-500/[35%]. Names
+// are case-insensitive.
+func Lookup(v Version, s string) (colors.Color, bool) {
+	name, opacity, hasOpacity := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "/")
+	c, ok := palettes[v][name]
+	if !ok || !hasOpacity {
+		return c, ok
+	}
+	a, ok := parseOpacity(opacity)
+	if !ok {
+		return colors.Color{}, false
+	}
+	return colors.New(c.R, c.G, c.B, uint8(math.Round(float64(c.A)*a))), true
+}
+
+// Format returns a parse format for the version, for use with
+// [colors.Parser.RegisterFormat]. See [Lookup].
+func Format(v Version) func(string) (colors.Color, bool) {
+	return func(s string) (colors.Color, bool) {
+		return Lookup(v, s)
+	}
+}
+
+// Register registers the version's palette with the parser as the tailwind
+// format, ex:
+//
+//	p := colors.NewParser()
+//	tailwind.Register(p, tailwind.V4)
+//	c, err := p.Parse("sky-500/75")
+//
+// The tailwind format is tried after the built-in formats, so names shared
+// with CSS (ex: black and white) are parsed as the CSS colors.
+func Register(p *colors.Parser, v Version) {
+	p.RegisterFormat("tailwind", Format(v))
+}
+
+// RegisterNames registers the names of the version's palette with
+// [colors.Register], for use with [colors.FromName] and [colors.Parse]. The
+// opacity modifier is only supported by the tailwind format. See [Register].
+//
+// Names that are already registered, such as those shared with CSS (ex:
+// black and white), or registered for another version, are not replaced.
+func RegisterNames(v Version) {
+	p := palettes[v]
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	// register in a stable order, so the names of colors with more than one
+	// name are stable
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := colors.FromName(name); !ok {
+			colors.RegisterName(name, p[name])
+		}
+	}
+}
+
+// parseOpacity parses an opacity modifier, ex: 75, [.35], or [35%].
+func parseOpacity(s string) (float64, bool) {
+	percent := true
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		if s = s[1 : len(s)-1]; strings.HasSuffix(s, "%") {
+			s = s[:len(s)-1]
+		} else {
+			percent = false
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	switch {
+	case err != nil, math.IsNaN(v), math.IsInf(v, 0), v < 0:
+		return 0, false
+	case percent:
+		v /= 100
+	}
+	if v > 1 {
+		return 0, false
+	}
+	return v, true
+}
+
+// palettes are the palettes, keyed by version.
+var palettes map[Version]map[string]colors.Color
+
+// shades are the shades of each color.
+var shades = [11]string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900", "950"}
+
+func init() {
+	palettes = make(map[Version]map[string]colors.Color)
+	p := make(map[string]colors.Color)
+	for name, v := range v3 {
+		for i, c := range v {
+			p[name+"-"+shades[i]] = colors.FromUint32(c<<8|0xff, colors.OrderRGBA)
+		}
+	}
+	palettes[V3] = p
+	p = make(map[string]colors.Color)
+	for name, v := range v4 {
+		for i, c := range v {
+			p[name+"-"+shades[i]] = colors.NewFloat(colors.SpaceOKLCH, c[0]/100, c[1], c[2], 1).Color()
+		}
+	}
+	palettes[V4] = p
+	for _, p := range palettes {
+		p["black"] = colors.FromColor(color.NRGBA{0, 0, 0, 0xff})
+		p["white"] = colors.FromColor(color.NRGBA{0xff, 0xff, 0xff, 0xff})
+		p["transparent"] = colors.FromColor(color.NRGBA{0, 0, 0, 0})
+	}
+}
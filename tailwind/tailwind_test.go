@@ -0,0 +1,119 @@
+package tailwind
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kenshaw/colors"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		v   Version
+		s   string
+		exp string
+	}{
+		{V3, "slate-900", "#0f172a"},
+		{V3, "Emerald-400", "#34d399"},
+		{V3, "sky-500", "#0ea5e9"},
+		{V3, "sky-500/75", "#0ea5e9bf"},
+		{V3, "sky-500/[.35]", "#0ea5e959"},
+		{V3, "sky-500/[35%]", "#0ea5e959"},
+		{V3, "white/50", "#ffffff80"},
+		{V3, "transparent", "#00000000"},
+		{V3, "rose-950", "#4c0519"},
+		{V4, "red-500", "#fb2c36"},
+		{V4, "blue-500", "#2b7fff"},
+		{V4, "neutral-950", "#0a0a0a"},
+		{V4, "slate-900/50", "#0f172b80"},
+		{V3, "red", ""},
+		{V3, "red-550", ""},
+		{V3, "sky-500/", ""},
+		{V3, "sky-500/101", ""},
+		{V3, "sky-500/[1.5]", ""},
+		{V3, "sky-500/x", ""},
+		{Version(2), "sky-500", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.v.String()+" "+test.s, func(t *testing.T) {
+			c, ok := Lookup(test.v, test.s)
+			switch {
+			case test.exp == "" && ok:
+				t.Fatalf("expected not ok, got: %s", c.AsWeb())
+			case test.exp == "":
+			case !ok:
+				t.Fatalf("expected ok")
+			case c.AsWeb() != test.exp:
+				t.Errorf("expected %s, got: %s", test.exp, c.AsWeb())
+			}
+		})
+	}
+}
+
+func TestPalettes(t *testing.T) {
+	v3, v4 := Palette(V3), Palette(V4)
+	if n, exp := len(v3), 22*11+3; n != exp {
+		t.Errorf("expected %d v3 colors, got: %d", exp, n)
+	}
+	if len(v3) != len(v4) {
+		t.Errorf("expected %d v4 colors, got: %d", len(v3), len(v4))
+	}
+	// the v4 palette is a perceptual refresh of the v3 palette
+	for name, a := range v3 {
+		b, ok := v4[name]
+		if !ok {
+			t.Errorf("expected v4 %s", name)
+			continue
+		}
+		x, y := a.Float().Convert(colors.SpaceOKLab), b.Float().Convert(colors.SpaceOKLab)
+		var d float64
+		for i := range x.Channels {
+			d += (x.Channels[i] - y.Channels[i]) * (x.Channels[i] - y.Channels[i])
+		}
+		if d = math.Sqrt(d); d > 0.05 {
+			t.Errorf("expected v4 %s (%s) to be similar to v3 (%s), got distance: %f", name, b.AsWeb(), a.AsWeb(), d)
+		}
+	}
+	if Palette(Version(2)) != nil {
+		t.Errorf("expected nil")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	p := colors.NewParser()
+	Register(p, V3)
+	for _, s := range []string{"slate-900", "sky-500/75", "rgb(from sky-500 r g b)"} {
+		if _, err := p.Parse(s); err != nil {
+			t.Errorf("%q: expected no error, got: %v", s, err)
+		}
+	}
+	c, err := p.Parse("red")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if exp := colors.Red.Color(); c != exp {
+		t.Errorf("expected %v, got: %v", exp, c)
+	}
+	if _, err := colors.Parse("slate-900"); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestRegisterNames(t *testing.T) {
+	RegisterNames(V3)
+	for _, s := range []string{"slate-900", "Slate_900", "slate900"} {
+		c, ok := colors.FromName(s)
+		if !ok {
+			t.Fatalf("%q: expected ok", s)
+		}
+		if v, exp := c.Name(), "slate-900"; v != exp {
+			t.Errorf("expected %s, got: %s", exp, v)
+		}
+	}
+	if c, _ := colors.Parse("#0f172a"); c.Name() != "slate-900" {
+		t.Errorf("expected slate-900, got: %s", c.Name())
+	}
+	if c, _ := colors.Parse("white"); c.Name() != "white" {
+		t.Errorf("expected white, got: %s", c.Name())
+	}
+}
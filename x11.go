@@ -0,0 +1,197 @@
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FromX11 converts a X11 color specification to a color, as parsed by
+// XParseColor, ex: rgb:ffff/e4e4/e1e1 or CIELab:92.7/8.8/5.4.
+//
+// Supports the following forms, where prefixes are case-insensitive:
+//
+//	rgb:<r>/<g>/<b>          - 1-4 hex digits per channel, scaled to 0-1
+//	rgbi:<r>/<g>/<b>         - intensities (0-1)
+//	CIEXYZ:<X>/<Y>/<Z>       - CIE XYZ, Y is 0-1
+//	CIEuvY:<u>/<v>/<Y>       - CIE u'v'Y
+//	CIExyY:<x>/<y>/<Y>       - CIE xyY
+//	CIELab:<L>/<a>/<b>       - CIE L*a*b*, L is 0-100
+//	CIELuv:<L>/<u>/<v>       - CIE L*u*v*, L is 0-100
+//	TekHVC:<H>/<V>/<C>       - Tektronix HVC, H is 0-360, V and C are 0-100
+//	#rrrgggbbb, #rrrrggggbbbb - the legacy hex forms with 3 or 4 digits per
+//	                           channel
+//
+// The rgb form is used by terminals when replying to OSC 4, 10, and 11
+// queries. See [Color.AsX11].
+//
+// Intensities (rgbi) are treated as gamma encoded sRGB, as with the default
+// X11 screen color characterization. The device-independent (CIE and TekHVC)
+// forms are relative to the D65 white point, and colors outside of the sRGB
+// gamut are gamut mapped. See [FloatColor.Color].
+func FromX11(s string) (Color, bool) {
+	c, err := parseX11(s)
+	if err != nil {
+		return Color{}, false
+	}
+	return c.Color(), true
+}
+
+// AsX11 returns the color formatted as a X11 rgb color specification, ex:
+// rgb:ffff/e4e4/e1e1, as used by terminals when replying to OSC 4, 10, and 11
+// queries.
+func (c Color) AsX11() string {
+	return fmt.Sprintf("rgb:%04x/%04x/%04x", uint16(c.R)*0x101, uint16(c.G)*0x101, uint16(c.B)*0x101)
+}
+
+// parseX11 parses a X11 color specification. Specifications with unknown
+// prefixes are rejected with errNoMatch.
+func parseX11(s string) (FloatColor, *ParseError) {
+	if strings.HasPrefix(s, "#") {
+		return parseX11Hex(s[1:])
+	}
+	i := strings.IndexByte(s, ':')
+	if i <= 0 {
+		return FloatColor{}, errNoMatch
+	}
+	prefix := strings.ToLower(s[:i])
+	switch prefix {
+	case "rgb", "rgbi", "ciexyz", "cieuvy", "ciexyy", "cielab", "cieluv", "tekhvc":
+	default:
+		return FloatColor{}, errNoMatch
+	}
+	var v [3]string
+	var offs [3]int
+	n, pos := 0, i+1
+	for rest, more := s[i+1:], true; more; n++ {
+		var part string
+		part, rest, more = strings.Cut(rest, "/")
+		if n < len(v) {
+			v[n], offs[n] = part, pos
+		}
+		pos += len(part) + 1
+	}
+	if n != len(v) {
+		return FloatColor{}, errorf(i+1, "expected 3 channels, got %d", n)
+	}
+	if prefix == "rgb" {
+		c := FloatColor{Space: SpaceSRGB, Alpha: 1}
+		for j, x := range v {
+			for k := 0; k < len(x); k++ {
+				if _, ok := hexDigit(x[k]); !ok {
+					return FloatColor{}, errorf(offs[j]+k, "invalid hex digit %q", x[k])
+				}
+			}
+			if len(x) == 0 || len(x) > 4 {
+				return FloatColor{}, errorf(offs[j], "channel %d must have 1-4 hex digits", j+1)
+			}
+			u, _ := strconv.ParseUint(x, 16, 16)
+			c.Channels[j] = float64(u) / float64(uint64(1)<<(4*len(x))-1)
+		}
+		return c, nil
+	}
+	var f [3]float64
+	for j, x := range v {
+		var err error
+		if f[j], err = strconv.ParseFloat(x, 64); err != nil || math.IsNaN(f[j]) || math.IsInf(f[j], 0) {
+			return FloatColor{}, errorf(offs[j], "invalid channel %d %q", j+1, x)
+		}
+	}
+	// tekhvc ranges
+	ranges := [3]float64{360, 100, math.Inf(1)}
+	var xyz [3]float64
+	switch prefix {
+	case "rgbi":
+		for j, x := range f {
+			if x < 0 || x > 1 {
+				return FloatColor{}, errorf(offs[j], "channel %d out of range 0-1", j+1)
+			}
+		}
+		return FloatColor{SpaceSRGB, f, 1}, nil
+	case "ciexyz":
+		xyz = f
+	case "cieuvy":
+		xyz = uvYToXYZ(f[0], f[1], f[2])
+	case "ciexyy":
+		if f[1] == 0 {
+			return FloatColor{}, errorf(offs[1], "channel 2 must not be 0")
+		}
+		xyz = [3]float64{f[0] * f[2] / f[1], f[2], (1 - f[0] - f[1]) * f[2] / f[1]}
+	case "cielab":
+		xyz = labToXYZ(f)
+		for j := range xyz {
+			xyz[j] *= d65[j] / d50[j]
+		}
+	case "cieluv":
+		xyz = luvToXYZ(f[0], f[1], f[2])
+	case "tekhvc":
+		for j, x := range f {
+			if x < 0 || x > ranges[j] {
+				return FloatColor{}, errorf(offs[j], "channel %d out of range %s", j+1, formatRange(0, ranges[j], 1, ""))
+			}
+		}
+		xyz = hvcToXYZ(f[0], f[1], f[2])
+	}
+	return FloatColor{SpaceXYZD65, xyz, 1}, nil
+}
+
+// parseX11Hex parses the legacy X11 #rrrgggbbb and #rrrrggggbbbb forms.
+// Other lengths are rejected with errNoMatch. See [FromWeb].
+func parseX11Hex(s string) (FloatColor, *ParseError) {
+	if (len(s) != 9 && len(s) != 12) || !isHex(s) {
+		return FloatColor{}, errNoMatch
+	}
+	n := len(s) / 3
+	c := FloatColor{Space: SpaceSRGB, Alpha: 1}
+	for i := range c.Channels {
+		u, _ := strconv.ParseUint(s[i*n:(i+1)*n], 16, 16)
+		c.Channels[i] = float64(u) / float64(uint64(1)<<(4*n)-1)
+	}
+	return c, nil
+}
+
+// uvYToXYZ converts CIE u'v'Y to CIE XYZ.
+func uvYToXYZ(u, v, y float64) [3]float64 {
+	if v == 0 {
+		return [3]float64{0, y, 0}
+	}
+	return [3]float64{y * 9 * u / (4 * v), y, y * (12 - 3*u - 20*v) / (4 * v)}
+}
+
+// luvToXYZ converts CIE L*u*v* to CIE XYZ (D65).
+func luvToXYZ(l, u, v float64) [3]float64 {
+	if l <= 0 {
+		return [3]float64{}
+	}
+	un, vn := whiteUV()
+	y := l / labKappa
+	if l > labKappa*labEpsilon {
+		y = math.Pow((l+16)/116, 3)
+	}
+	return uvYToXYZ(u/(13*l)+un, v/(13*l)+vn, y)
+}
+
+// hvcToXYZ converts Tektronix HVC to CIE XYZ (D65).
+//
+// See: Xcms TekHVC.c in libX11.
+func hvcToXYZ(h, v, c float64) [3]float64 {
+	if v <= 0 {
+		return [3]float64{}
+	}
+	// chroma scale, and the u'v' of the "best red", which is at a hue of 0
+	const scale, bestRedU, bestRedV = 7.50725, 0.7127, 0.4931
+	un, vn := whiteUV()
+	h = (h + math.Atan2(bestRedV-vn, bestRedU-un)*180/math.Pi) * math.Pi / 180
+	y := v / labKappa
+	if v > labKappa*labEpsilon {
+		y = math.Pow((v+16)/116, 3)
+	}
+	return uvYToXYZ(c*math.Cos(h)/(v*scale)+un, c*math.Sin(h)/(v*scale)+vn, y)
+}
+
+// whiteUV returns the CIE u'v' of the D65 white point.
+func whiteUV() (float64, float64) {
+	d := d65[0] + 15*d65[1] + 3*d65[2]
+	return 4 * d65[0] / d, 9 * d65[1] / d
+}
@@ -0,0 +1,178 @@
+// Package x11 provides the X11 color name database (rgb.txt), for use with
+// the colors package.
+//
+// The X11 database has names not in CSS, such as DarkOliveGreen3, gray47,
+// navy blue, and LightGoldenrod, and defines some names differently than
+// CSS, such as gray, green, maroon, and purple. The database is not enabled
+// by default. Use [Register] to parse X11 names with a [colors.Parser], or
+// [RegisterNames] to register the names for use with [colors.FromName]. See
+// [Policy] for how conflicting names are handled.
+//
+// See [Load] for loading a custom rgb.txt file.
+package x11
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kenshaw/colors"
+)
+
+// Policy is the policy for names defined differently by X11 and CSS, such as
+// gray, green, maroon, and purple.
+//
+// Regardless of the policy, the X11 colors are available using their x11
+// prefixed names (ex: x11gray), and the CSS colors using their web prefixed
+// names (ex: webgray).
+type Policy int
+
+// Policies.
+const (
+	// PreferCSS uses the CSS colors for conflicting names.
+	PreferCSS Policy = iota
+	// PreferX11 uses the X11 colors for conflicting names.
+	PreferX11
+)
+
+// String satisfies the [fmt.Stringer] interface.
+func (policy Policy) String() string {
+	if policy == PreferX11 {
+		return "x11"
+	}
+	return "css"
+}
+
+// Database is a X11 color name database.
+type Database struct {
+	// colors are the colors, keyed by their normalized name.
+	colors map[string]colors.Color
+	// names are the names, in the order they were loaded.
+	names []string
+}
+
+// Default is the X11 color name database, as distributed with X.Org.
+var Default *Database
+
+//go:embed rgb.txt
+var rgbTxt string
+
+func init() {
+	var err error
+	if Default, err = Load(strings.NewReader(rgbTxt)); err != nil {
+		panic(err)
+	}
+}
+
+// Load loads a X11 color name database from r, in the rgb.txt format, where
+// each line has a red, green, and blue (0-255), followed by a name, ex: 255
+// 250 250 snow. Blank lines, and lines starting with ! are ignored.
+func Load(r io.Reader) (*Database, error) {
+	db := &Database{colors: make(map[string]colors.Color)}
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		str := strings.TrimSpace(s.Text())
+		if str == "" || str[0] == '!' {
+			continue
+		}
+		fields := strings.Fields(str)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("line %d: expected red, green, blue, and a name", line)
+		}
+		var v [3]uint8
+		for i := range v {
+			u, err := strconv.ParseUint(fields[i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q", line, fields[i])
+			}
+			v[i] = uint8(u)
+		}
+		name := strings.Join(fields[3:], " ")
+		key := normalize(name)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: invalid name %q", line, name)
+		}
+		db.colors[key] = colors.New(v[0], v[1], v[2], 0xff)
+		db.names = append(db.names, name)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// LoadFile loads a X11 color name database from the named rgb.txt file, ex:
+// /usr/share/X11/rgb.txt. See [Load].
+func LoadFile(name string) (*Database, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Lookup returns the named color. Names are case-insensitive and ignore
+// whitespace, such that navy blue, NavyBlue, and navyblue are equivalent.
+func (db *Database) Lookup(name string) (colors.Color, bool) {
+	c, ok := db.colors[normalize(name)]
+	return c, ok
+}
+
+// Names returns the database's names, in the order they were loaded,
+// including variants that differ only by case or whitespace (ex: navy blue
+// and NavyBlue).
+func (db *Database) Names() []string {
+	return append([]string(nil), db.names...)
+}
+
+// Format returns a parse format for the database using the policy, for use
+// with [colors.Parser.RegisterFormat]. With [PreferX11], the format parses
+// both X11 and CSS names.
+func (db *Database) Format(policy Policy) func(string) (colors.Color, bool) {
+	if policy == PreferX11 {
+		return func(s string) (colors.Color, bool) {
+			if c, ok := db.Lookup(s); ok {
+				return c, true
+			}
+			return colors.FromName(s)
+		}
+	}
+	return db.Lookup
+}
+
+// Register registers the database with the parser using the policy.
+//
+// With [PreferCSS], the database is registered as the x11-name format, which
+// is tried after the built-in formats. With [PreferX11], the database
+// replaces the built-in name format, and CSS names not in the database
+// continue to be parsed.
+func Register(p *colors.Parser, db *Database, policy Policy) {
+	if policy == PreferX11 {
+		p.RegisterFormat("name", db.Format(policy))
+		return
+	}
+	p.RegisterFormat("x11-name", db.Format(policy))
+}
+
+// RegisterNames registers the database's names with [colors.RegisterName],
+// using the policy, for use with [colors.FromName] and [colors.Parse]. Names
+// are registered in their normalized form, ex: darkolivegreen3.
+func RegisterNames(db *Database, policy Policy) {
+	for _, name := range db.names {
+		key := normalize(name)
+		if _, ok := colors.FromName(key); ok && policy != PreferX11 {
+			continue
+		}
+		colors.RegisterName(key, db.colors[key])
+	}
+}
+
+// normalize normalizes a name, lower casing it and removing whitespace.
+func normalize(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "")
+}
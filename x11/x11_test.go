@@ -0,0 +1,148 @@
+package x11
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kenshaw/colors"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"DarkOliveGreen3", "#a2cd5a"},
+		{"darkolivegreen3", "#a2cd5a"},
+		{"gray47", "#787878"},
+		{"grey47", "#787878"},
+		{"navy blue", "#000080"},
+		{"NavyBlue", "#000080"},
+		{"  navy  blue ", "#000080"},
+		{"LightGoldenrod", "#eedd82"},
+		{"gray", "#bebebe"},
+		{"web gray", "#808080"},
+		{"RebeccaPurple", "#663399"},
+		{"navy-blue", ""},
+		{"gray101", ""},
+		{"", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, ok := Default.Lookup(test.s)
+			switch {
+			case test.exp == "" && ok:
+				t.Fatalf("expected not ok, got: %s", c.AsWeb())
+			case test.exp == "":
+			case !ok:
+				t.Fatalf("expected ok")
+			case c.AsWeb() != test.exp:
+				t.Errorf("expected %s, got: %s", test.exp, c.AsWeb())
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	db, err := Load(strings.NewReader("! comment\n\n255 250 250\t\tsnow\n  0   0 128\t\tnavy blue\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if v, exp := strings.Join(db.Names(), ","), "snow,navy blue"; v != exp {
+		t.Errorf("expected %q, got: %q", exp, v)
+	}
+	if _, ok := db.Lookup("NavyBlue"); !ok {
+		t.Errorf("expected ok")
+	}
+	for _, s := range []string{
+		"255 250 snow",
+		"255 250 256 snow",
+		"255 250 -1 snow",
+		"255 250 x snow",
+		"255 250 250",
+	} {
+		if _, err := Load(strings.NewReader("! comment\n" + s + "\n")); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+			t.Errorf("%q: expected line 2 error, got: %v", s, err)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if n := len(Default.Names()); n < 750 {
+		t.Errorf("expected at least 750 names, got: %d", n)
+	}
+	// conflicting names
+	for _, name := range []string{"gray", "grey", "green", "maroon", "purple"} {
+		x, _ := Default.Lookup(name)
+		c, ok := colors.FromName(name)
+		if !ok {
+			t.Fatalf("%s: expected ok", name)
+		}
+		if x.AsWeb() == c.AsWeb() {
+			t.Errorf("%s: expected x11 and css colors to differ", name)
+		}
+		if v, _ := Default.Lookup("x11" + name); v.AsWeb() != x.AsWeb() {
+			t.Errorf("%s: expected x11%s to be %s, got: %s", name, name, x.AsWeb(), v.AsWeb())
+		}
+		if v, _ := Default.Lookup("web" + name); v.AsWeb() != c.AsWeb() {
+			t.Errorf("%s: expected web%s to be %s, got: %s", name, name, c.AsWeb(), v.AsWeb())
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		s      string
+		exp    string
+	}{
+		{PreferCSS, "gray", "#808080"},
+		{PreferCSS, "DarkOliveGreen3", "#a2cd5a"},
+		{PreferCSS, "navy blue", "#000080"},
+		{PreferCSS, "rebeccapurple", "#663399"},
+		{PreferX11, "gray", "#bebebe"},
+		{PreferX11, "DarkOliveGreen3", "#a2cd5a"},
+		{PreferX11, "rebeccapurple", "#663399"},
+		{PreferX11, "hotpink", "#ff69b4"},
+		{PreferX11, "misty-rose", "#ffe4e1"},
+	}
+	for _, test := range tests {
+		t.Run(test.policy.String()+" "+test.s, func(t *testing.T) {
+			p := colors.NewParser()
+			Register(p, Default, test.policy)
+			c, err := p.Parse(test.s)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if v := c.AsWeb(); v != test.exp {
+				t.Errorf("expected %s, got: %s", test.exp, v)
+			}
+		})
+	}
+	if _, err := colors.Parse("DarkOliveGreen3"); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestRegisterNames(t *testing.T) {
+	check := func(name, exp string) {
+		t.Helper()
+		c, ok := colors.FromName(name)
+		switch {
+		case !ok:
+			t.Errorf("%s: expected ok", name)
+		case c.AsWeb() != exp:
+			t.Errorf("%s: expected %s, got: %s", name, exp, c.AsWeb())
+		}
+	}
+	RegisterNames(Default, PreferCSS)
+	check("DarkOliveGreen3", "#a2cd5a")
+	check("navy blue", "#000080")
+	check("gray47", "#787878")
+	check("gray", "#808080")
+	check("x11gray", "#bebebe")
+	RegisterNames(Default, PreferX11)
+	check("gray", "#bebebe")
+	check("green", "#00ff00")
+	check("webgreen", "#008000")
+}
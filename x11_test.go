@@ -0,0 +1,91 @@
+package colors
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestFromX11(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp color.NRGBA
+	}{
+		{"rgb:ffff/ffff/ffff", color.NRGBA{255, 255, 255, 255}},
+		{"rgb:0000/0000/0000", color.NRGBA{0, 0, 0, 255}},
+		{"rgb:f/8/0", color.NRGBA{255, 136, 0, 255}},
+		{"rgb:80/8000/800", color.NRGBA{128, 128, 128, 255}},
+		{"RGB:FF/0/00", color.NRGBA{255, 0, 0, 255}},
+		{"rgbi:1/0.5/0", color.NRGBA{255, 128, 0, 255}},
+		{"rgbi:1e0/.5/0", color.NRGBA{255, 128, 0, 255}},
+		{"CIEXYZ:0.9505/1/1.089", color.NRGBA{255, 255, 255, 255}},
+		{"ciexyz:0.4124/0.2126/0.0193", color.NRGBA{255, 0, 0, 255}},
+		{"CIExyY:0.3127/0.3290/1", color.NRGBA{255, 255, 255, 255}},
+		{"CIExyY:0.64/0.33/0.2126", color.NRGBA{255, 0, 0, 255}},
+		{"CIEuvY:0.1978/0.4683/1", color.NRGBA{255, 255, 255, 255}},
+		{"CIEuvY:0.4507/0.5229/0.2126", color.NRGBA{255, 0, 0, 255}},
+		{"CIELab:100/0/0", color.NRGBA{255, 255, 255, 255}},
+		{"CIELab:53.2408/80.0925/67.2032", color.NRGBA{255, 0, 0, 255}},
+		{"CIELab:0/0/0", color.NRGBA{0, 0, 0, 255}},
+		{"CIELuv:100/0/0", color.NRGBA{255, 255, 255, 255}},
+		{"CIELuv:53.2408/175.0151/37.7564", color.NRGBA{255, 0, 0, 255}},
+		{"CIELuv:0/0/0", color.NRGBA{0, 0, 0, 255}},
+		{"TekHVC:0/100/0", color.NRGBA{255, 255, 255, 255}},
+		{"TekHVC:0/50/0", color.NRGBA{119, 119, 119, 255}},
+		{"TekHVC:0/0/0", color.NRGBA{0, 0, 0, 255}},
+		{"#fff800000", color.NRGBA{255, 128, 0, 255}},
+		{"#ffff80000000", color.NRGBA{255, 128, 0, 255}},
+		{"rgb:", color.NRGBA{}},
+		{"rgb:0/0", color.NRGBA{}},
+		{"rgb:0//0", color.NRGBA{}},
+		{"rgb:0/0/0/0", color.NRGBA{}},
+		{"rgb:fffff/0/0", color.NRGBA{}},
+		{"rgb:g/0/0", color.NRGBA{}},
+		{"rgb:-1/0/0", color.NRGBA{}},
+		{"rgbi:2/0/0", color.NRGBA{}},
+		{"rgbi:1/-0.5/0", color.NRGBA{}},
+		{"rgbi:a/0/0", color.NRGBA{}},
+		{"rgbi:nan/0/0", color.NRGBA{}},
+		{"CIEXYZ:inf/0/0", color.NRGBA{}},
+		{"CIExyY:0.3/0/1", color.NRGBA{}},
+		{"TekHVC:400/50/0", color.NRGBA{}},
+		{"TekHVC:0/150/0", color.NRGBA{}},
+		{"TekHVC:0/50/-1", color.NRGBA{}},
+		{"unknown:0/0/0", color.NRGBA{}},
+		{":0/0/0", color.NRGBA{}},
+		{"#fff80000", color.NRGBA{}},
+		{"#ffff8000000g", color.NRGBA{}},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, ok := FromX11(test.s)
+			switch {
+			case test.exp == (color.NRGBA{}) && ok:
+				t.Fatalf("expected error, got: %v", c)
+			case test.exp == (color.NRGBA{}):
+			case !ok:
+				t.Fatalf("expected ok")
+			case !c.Is(test.exp):
+				t.Errorf("expected %v, got: %#v", test.exp, c)
+			}
+		})
+	}
+}
+
+func TestAsX11(t *testing.T) {
+	tests := []struct {
+		c   Color
+		exp string
+	}{
+		{Black.Color(), "rgb:0000/0000/0000"},
+		{White.Color(), "rgb:ffff/ffff/ffff"},
+		{Mistyrose.Color(), "rgb:ffff/e4e4/e1e1"},
+		{New(1, 0x80, 0xfe, 0x80), "rgb:0101/8080/fefe"},
+	}
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			if s := test.c.AsX11(); s != test.exp {
+				t.Errorf("expected %q, got: %q", test.exp, s)
+			}
+		})
+	}
+}
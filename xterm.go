@@ -0,0 +1,190 @@
+package colors
+
+import (
+	"strings"
+)
+
+// ANSIPalette is a terminal's 16 ANSI system colors, as 0xrrggbb, in the
+// order black, red, green, yellow, blue, magenta, cyan, white, followed by
+// the bright variants of each.
+//
+// The system colors are the first 16 colors of the xterm 256 color palette,
+// and are defined differently by each terminal. See [WithANSIPalette].
+type ANSIPalette [16]uint32
+
+// ANSI palettes.
+var (
+	// ANSIXterm is the xterm palette.
+	ANSIXterm = ANSIPalette{
+		0x000000, 0xcd0000, 0x00cd00, 0xcdcd00, 0x0000ee, 0xcd00cd, 0x00cdcd, 0xe5e5e5,
+		0x7f7f7f, 0xff0000, 0x00ff00, 0xffff00, 0x5c5cff, 0xff00ff, 0x00ffff, 0xffffff,
+	}
+	// ANSIVGA is the IBM VGA palette, as used by the Linux console.
+	ANSIVGA = ANSIPalette{
+		0x000000, 0xaa0000, 0x00aa00, 0xaa5500, 0x0000aa, 0xaa00aa, 0x00aaaa, 0xaaaaaa,
+		0x555555, 0xff5555, 0x55ff55, 0xffff55, 0x5555ff, 0xff55ff, 0x55ffff, 0xffffff,
+	}
+	// ANSIWindows is the Windows Console palette (Campbell), as used by
+	// Windows 10 and later.
+	ANSIWindows = ANSIPalette{
+		0x0c0c0c, 0xc50f1f, 0x13a10e, 0xc19c00, 0x0037da, 0x881798, 0x3a96dd, 0xcccccc,
+		0x767676, 0xe74856, 0x16c60c, 0xf9f1a5, 0x3b78ff, 0xb4009e, 0x61d6d6, 0xf2f2f2,
+	}
+	// ANSITerminalApp is the macOS Terminal.app palette.
+	ANSITerminalApp = ANSIPalette{
+		0x000000, 0xc23621, 0x25bc24, 0xadad27, 0x492ee1, 0xd338d3, 0x33bbc8, 0xcbcccd,
+		0x818383, 0xfc391f, 0x31e722, 0xeaec23, 0x5833ff, 0xf935f8, 0x14f0f0, 0xe9ebeb,
+	}
+)
+
+// Color returns the xterm 256 color palette color at index i, where 0-15 are
+// the palette's system colors, 16-231 are a 6x6x6 color cube, and 232-255 are
+// a 24 step gray ramp.
+func (p ANSIPalette) Color(i uint8) Color {
+	switch {
+	case i < 16:
+		return FromUint32(p[i]<<8|0xff, OrderRGBA)
+	case i < 232:
+		i -= 16
+		return New(cubeLevel(i/36), cubeLevel(i/6%6), cubeLevel(i%6), 0xff)
+	}
+	v := 8 + 10*(i-232)
+	return New(v, v, v, 0xff)
+}
+
+// cubeLevel returns the value of a xterm color cube level (0-5).
+func cubeLevel(i uint8) uint8 {
+	if i == 0 {
+		return 0
+	}
+	return 55 + 40*i
+}
+
+// ansiNames are the names of the ANSI system colors.
+var ansiNames = [16]string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"brightblack", "brightred", "brightgreen", "brightyellow", "brightblue", "brightmagenta", "brightcyan", "brightwhite",
+}
+
+// WithANSIPalette is a parse option to set the palette used for the ANSI
+// system colors, ex: WithANSIPalette(ANSIVGA). Without a palette, the xterm
+// palette is used. See [FromXterm].
+func WithANSIPalette(p ANSIPalette) Option {
+	return func(o *options) {
+		o.ansi = &p
+	}
+}
+
+// WithXtermIndexes is a parse option to set whether bare xterm 256 color
+// palette indexes (ex: 208) are parsed. Without the option, indexes must
+// have the xterm prefix (ex: xterm:208), or be wrapped in color(). See
+// [FromXterm].
+func WithXtermIndexes(xtermIndexes bool) Option {
+	return func(o *options) {
+		o.xtermIndexes = xtermIndexes
+	}
+}
+
+// FromXterm converts a xterm 256 color palette index or ANSI color name to a
+// color, using the xterm palette for the system colors, ex: xterm:208,
+// color(244), ansi:brightred, or ansi:9.
+//
+// Supports the following forms:
+//
+//	xterm:<index>   - a palette index (0-255)
+//	color(<index>)  - a palette index (0-255)
+//	<index>         - a palette index (0-255)
+//	ansi:<name>     - an ANSI color name, ex: black, red, or brightwhite
+//	ansi:<index>    - an ANSI color index (0-15)
+//
+// [Parse] only parses bare indexes with [WithXtermIndexes].
+//
+// The prefixes and names are case-insensitive, and the bright names may
+// separate bright from the color with a hyphen, underscore, or space, such
+// that ansi:bright-red and ansi:BrightRed are equivalent to ansi:brightred.
+// See [WithANSIPalette] for parsing with other palettes.
+func FromXterm(s string) (Color, bool) {
+	c, err := parseXterm(&ANSIXterm, s, true)
+	return c, err == nil
+}
+
+// fromXterm converts a xterm string to a color using the options.
+func (o options) fromXterm(s string) (Color, *ParseError) {
+	if o.ansi == nil {
+		return parseXterm(&ANSIXterm, s, o.xtermIndexes)
+	}
+	return parseXterm(o.ansi, s, o.xtermIndexes)
+}
+
+// parseXterm parses a xterm 256 color palette index or ANSI color name. Bare
+// indexes are only parsed when bare is true.
+func parseXterm(p *ANSIPalette, s string, bare bool) (Color, *ParseError) {
+	if len(s) > 5 && strings.EqualFold(s[:5], "ansi:") {
+		if i, ok := parseIndex(s[5:]); ok && i < 16 {
+			return p.Color(uint8(i)), nil
+		}
+		if i, ok := ansiIndex(s[5:]); ok {
+			return p.Color(uint8(i)), nil
+		}
+		return Color{}, errorf(5, "unknown ANSI color %q", s[5:])
+	}
+	off := 0
+	switch args, i, err := funcArgs(s, "color"); {
+	case err == nil:
+		off = i + len(args) - len(strings.TrimLeft(args, " \t\n\r\f"))
+		s = strings.TrimSpace(args)
+	case len(s) > 6 && strings.EqualFold(s[:6], "xterm:"):
+		off, s = 6, s[6:]
+	case !bare:
+		return Color{}, errNoMatch
+	}
+	i, ok := parseIndex(s)
+	switch {
+	case !ok && off == 6:
+		return Color{}, errorf(off, "invalid index %q", s)
+	case !ok:
+		return Color{}, errNoMatch
+	case i > 255:
+		return Color{}, errorf(off, "index %d out of range 0-255", i)
+	}
+	return p.Color(uint8(i)), nil
+}
+
+// ansiIndex returns the index of the ANSI color name, ex: red or
+// bright-red.
+func ansiIndex(s string) (int, bool) {
+	bright := 0
+	if len(s) > 6 && strings.EqualFold(s[:6], "bright") {
+		bright, s = 8, s[6:]
+		if s[0] == '-' || s[0] == '_' || s[0] == ' ' {
+			s = s[1:]
+		}
+	}
+	for i, name := range ansiNames[:8] {
+		if strings.EqualFold(s, name) {
+			return bright + i, true
+		}
+	}
+	return 0, false
+}
+
+// isIndex returns true when s is a palette index of 1-3 decimal digits.
+func isIndex(s string) bool {
+	_, ok := parseIndex(s)
+	return ok
+}
+
+// parseIndex parses a palette index of 1-3 decimal digits.
+func parseIndex(s string) (int, bool) {
+	if len(s) == 0 || len(s) > 3 {
+		return 0, false
+	}
+	i := 0
+	for j := 0; j < len(s); j++ {
+		if !isDigit(s[j]) {
+			return 0, false
+		}
+		i = i*10 + int(s[j]-'0')
+	}
+	return i, true
+}
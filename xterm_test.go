@@ -0,0 +1,120 @@
+package colors
+
+import (
+	"testing"
+)
+
+func TestFromXterm(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp string
+	}{
+		{"0", "#000000"},
+		{"1", "#cd0000"},
+		{"15", "#ffffff"},
+		{"16", "#000000"},
+		{"21", "#0000ff"},
+		{"196", "#ff0000"},
+		{"208", "#ff8700"},
+		{"231", "#ffffff"},
+		{"232", "#080808"},
+		{"244", "#808080"},
+		{"255", "#eeeeee"},
+		{"007", "#e5e5e5"},
+		{"xterm:208", "#ff8700"},
+		{"XTerm:0", "#000000"},
+		{"color(244)", "#808080"},
+		{"Color( 208 )", "#ff8700"},
+		{"ansi:black", "#000000"},
+		{"ansi:red", "#cd0000"},
+		{"ansi:brightred", "#ff0000"},
+		{"ANSI:BrightBlue", "#5c5cff"},
+		{"ansi:bright-magenta", "#ff00ff"},
+		{"ansi:bright white", "#ffffff"},
+		{"ansi:9", "#ff0000"},
+		{"256", ""},
+		{"-1", ""},
+		{"+1", ""},
+		{"1.5", ""},
+		{"", ""},
+		{"color()", ""},
+		{"color(1 2)", ""},
+		{"ansi:", ""},
+		{"ansi:16", ""},
+		{"ansi:orange", ""},
+		{"ansi:brightbrightred", ""},
+		{"ansi:9red", ""},
+		{"ansi:bright--red", ""},
+		{"ansi:b-r-i-g-h-t-red", ""},
+		{"xterm:", ""},
+		{"xterm:256", ""},
+		{"xterm:red", ""},
+		{"red", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, ok := FromXterm(test.s)
+			switch {
+			case test.exp == "" && ok:
+				t.Fatalf("expected not ok, got: %s", c.AsWeb())
+			case test.exp == "":
+			case !ok:
+				t.Fatalf("expected ok")
+			case c.AsWeb() != test.exp:
+				t.Errorf("expected %s, got: %s", test.exp, c.AsWeb())
+			}
+		})
+	}
+}
+
+func TestANSIPalette(t *testing.T) {
+	tests := []struct {
+		p   ANSIPalette
+		s   string
+		exp string
+	}{
+		{ANSIXterm, "ansi:yellow", "#cdcd00"},
+		{ANSIVGA, "ansi:yellow", "#aa5500"},
+		{ANSIVGA, "xterm:3", "#aa5500"},
+		{ANSIWindows, "ansi:yellow", "#c19c00"},
+		{ANSITerminalApp, "ansi:yellow", "#adad27"},
+		{ANSIVGA, "xterm:208", "#ff8700"},
+		{ANSIVGA, "red", "#ff0000"},
+	}
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			c, err := Parse(test.s, WithANSIPalette(test.p))
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if v := c.AsWeb(); v != test.exp {
+				t.Errorf("expected %s, got: %s", test.exp, v)
+			}
+		})
+	}
+	// the cube and gray ramp are the same for all palettes
+	for i := 16; i < 256; i++ {
+		if a, b := ANSIXterm.Color(uint8(i)), ANSIWindows.Color(uint8(i)); a != b {
+			t.Errorf("%d: expected %s, got: %s", i, a.AsWeb(), b.AsWeb())
+		}
+	}
+	if c := ANSIXterm.Color(196); c.Name() != "red" {
+		t.Errorf("expected red, got: %q", c.Name())
+	}
+}
+
+func TestXtermIndexes(t *testing.T) {
+	if c, err := Parse("0"); err == nil {
+		t.Errorf("expected error, got: %s", c.AsWeb())
+	}
+	c, err := Parse("208", WithXtermIndexes(true))
+	switch {
+	case err != nil:
+		t.Fatalf("expected no error, got: %v", err)
+	case c.AsWeb() != "#ff8700":
+		t.Errorf("expected #ff8700, got: %s", c.AsWeb())
+	}
+	if c, err := Parse("ffe4e1", WithXtermIndexes(true)); err != nil || c.AsWeb() != "#ffe4e1" {
+		t.Errorf("expected #ffe4e1, got: %s %v", c.AsWeb(), err)
+	}
+}
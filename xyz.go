@@ -0,0 +1,128 @@
+package colors
+
+import (
+	"math"
+)
+
+// matrix is a 3x3 matrix.
+type matrix [3][3]float64
+
+// mul multiplies the matrix with v.
+func (m matrix) mul(v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+// srgbToLinear converts a gamma encoded sRGB channel to linear light.
+func srgbToLinear(v float64) float64 {
+	if a := math.Abs(v); a > 0.04045 {
+		return math.Copysign(math.Pow((a+0.055)/1.055, 2.4), v)
+	}
+	return v / 12.92
+}
+
+// linearToSRGB converts a linear light channel to gamma encoded sRGB.
+func linearToSRGB(v float64) float64 {
+	if a := math.Abs(v); a > 0.0031308 {
+		return math.Copysign(1.055*math.Pow(a, 1/2.4)-0.055, v)
+	}
+	return 12.92 * v
+}
+
+// srgbToXYZ65 converts gamma encoded sRGB to CIE XYZ (D65).
+func srgbToXYZ65(r, g, b float64) [3]float64 {
+	return linearSRGBToXYZ65.mul([3]float64{srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)})
+}
+
+// xyz65ToSRGB converts CIE XYZ (D65) to gamma encoded sRGB.
+func xyz65ToSRGB(v [3]float64) (float64, float64, float64) {
+	v = xyz65ToLinearSRGB.mul(v)
+	return linearToSRGB(v[0]), linearToSRGB(v[1]), linearToSRGB(v[2])
+}
+
+// inGamut returns true when r, g, b are within the sRGB gamut.
+func inGamut(r, g, b float64) bool {
+	const eps = 0.000075
+	return -eps <= r && r <= 1+eps &&
+		-eps <= g && g <= 1+eps &&
+		-eps <= b && b <= 1+eps
+}
+
+// White points.
+var (
+	// d50 is the CIE standard illuminant D50 white point.
+	d50 = [3]float64{0.3457 / 0.3585, 1, (1 - 0.3457 - 0.3585) / 0.3585}
+	// d65 is the CIE standard illuminant D65 white point.
+	d65 = [3]float64{0.3127 / 0.3290, 1, (1 - 0.3127 - 0.3290) / 0.3290}
+)
+
+// Conversion matrices.
+//
+// See: https://www.w3.org/TR/css-color-4/#color-conversion-code
+var (
+	linearSRGBToXYZ65 = matrix{
+		{506752.0 / 1228815, 87881.0 / 245763, 12673.0 / 70218},
+		{87098.0 / 409605, 175762.0 / 245763, 12673.0 / 175545},
+		{7918.0 / 409605, 87881.0 / 737289, 1001167.0 / 1053270},
+	}
+	xyz65ToLinearSRGB = matrix{
+		{12831.0 / 3959, -329.0 / 214, -1974.0 / 3959},
+		{-851781.0 / 878810, 1648619.0 / 878810, 36519.0 / 878810},
+		{705.0 / 12673, -2585.0 / 12673, 705.0 / 667},
+	}
+	// xyz65ToXYZ50 is the Bradford chromatic adaptation from D65 to D50.
+	xyz65ToXYZ50 = matrix{
+		{1.0479297925449969, 0.022946870601609652, -0.05019226628920524},
+		{0.02962780877005599, 0.9904344267538799, -0.017073799063418826},
+		{-0.009243040646204504, 0.015055191490298152, 0.7518742814281371},
+	}
+	// xyz50ToXYZ65 is the Bradford chromatic adaptation from D50 to D65.
+	xyz50ToXYZ65 = matrix{
+		{0.955473421488075, -0.02309845494876471, 0.06325924320057072},
+		{-0.0283697093338637, 1.0099953980813041, 0.021041441191917323},
+		{0.012314014864481998, -0.020507649298898964, 1.330365926242124},
+	}
+	linearP3ToXYZ65 = matrix{
+		{608311.0 / 1250200, 189793.0 / 714400, 198249.0 / 1000160},
+		{35783.0 / 156275, 247089.0 / 357200, 198249.0 / 2500400},
+		{0, 32229.0 / 714400, 5220557.0 / 5000800},
+	}
+	xyz65ToLinearP3 = matrix{
+		{446124.0 / 178915, -333277.0 / 357830, -72051.0 / 178915},
+		{-14852.0 / 17905, 63121.0 / 35810, 423.0 / 17905},
+		{11844.0 / 330415, -50337.0 / 660830, 316169.0 / 330415},
+	}
+	linearA98ToXYZ65 = matrix{
+		{573536.0 / 994567, 263643.0 / 1420810, 187206.0 / 994567},
+		{591459.0 / 1989134, 6239551.0 / 9945670, 374412.0 / 4972835},
+		{53769.0 / 1989134, 351524.0 / 4972835, 4929758.0 / 4972835},
+	}
+	xyz65ToLinearA98 = matrix{
+		{1829569.0 / 896150, -506331.0 / 896150, -308931.0 / 896150},
+		{-851781.0 / 878810, 1648619.0 / 878810, 36519.0 / 878810},
+		{16779.0 / 1248040, -147721.0 / 1248040, 1266979.0 / 1248040},
+	}
+	linearProPhotoToXYZ50 = matrix{
+		{0.79776664490064230, 0.13518129740053308, 0.03134773412839220},
+		{0.28807482881940130, 0.71183523424187300, 0.00008993693872564},
+		{0, 0, 0.82510460251046020},
+	}
+	xyz50ToLinearProPhoto = matrix{
+		{1.34578688164715830, -0.25557208737979464, -0.05110186497554526},
+		{-0.54463070512490190, 1.50824774284514680, 0.02052744743642139},
+		{0, 0, 1.21196754563894520},
+	}
+	linearRec2020ToXYZ65 = matrix{
+		{63426534.0 / 99577255, 20160776.0 / 139408157, 47086771.0 / 278816314},
+		{26158966.0 / 99577255, 472592308.0 / 697040785, 8267143.0 / 139408157},
+		{0, 19567812.0 / 697040785, 295819943.0 / 278816314},
+	}
+	xyz65ToLinearRec2020 = matrix{
+		{30757411.0 / 17917100, -6372589.0 / 17917100, -4539589.0 / 17917100},
+		{-19765991.0 / 29648200, 47925759.0 / 29648200, 467509.0 / 29648200},
+		{792561.0 / 44930125, -1921689.0 / 44930125, 42328811.0 / 44930125},
+	}
+)